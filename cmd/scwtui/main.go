@@ -18,7 +18,8 @@ type RootState struct {
 type CLI struct {
 	Config config.Config `embed:""`
 
-	Tui TuiCmd `cmd:"" default:"withargs"`
+	Tui    TuiCmd    `cmd:"" default:"withargs"`
+	Tokens TokensCmd `cmd:"" help:"Manage the Cockpit tokens created by scwtui."`
 }
 
 func main() {
@@ -38,6 +39,9 @@ func main() {
 		Version: gitVersion(),
 	}
 
+	// the resources have no logger, e.g. to report the failures after an action.
+	slog.SetDefault(logger)
+
 	logger.Info("starting scwtui", slog.String("version", rs.Version))
 
 	err = ctx.Run(rs)
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/discovery/scaleway"
+	"github.com/cyclimse/scwtui/internal/observability/cockpit"
+)
+
+type TokensCmd struct {
+	List   TokensListCmd   `cmd:"" help:"List the Cockpit tokens created by scwtui on this machine."`
+	Revoke TokensRevokeCmd `cmd:"" help:"Revoke the Cockpit tokens created by scwtui on this machine."`
+}
+
+type TokensListCmd struct {
+	Name string `default:"" help:"List the tokens with this exact name instead, such as the ones of another machine."`
+}
+
+func (cmd *TokensListCmd) Run(rs *RootState) error {
+	ctx := context.Background()
+
+	c, projectIDsToNames, err := tokensCockpit(ctx, rs)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := c.ListTokens(ctx, keys(projectIDsToNames), cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tNAME\tID\tCREATED")
+	for _, token := range tokens {
+		created := "-"
+		if token.CreatedAt != nil {
+			created = token.CreatedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", projectIDsToNames[token.ProjectID], token.Name, token.ID, created)
+	}
+	return w.Flush()
+}
+
+type TokensRevokeCmd struct {
+	Name string `default:"" help:"Revoke the tokens with this exact name instead, such as the ones of another machine."`
+}
+
+func (cmd *TokensRevokeCmd) Run(rs *RootState) error {
+	ctx := context.Background()
+
+	c, projectIDsToNames, err := tokensCockpit(ctx, rs)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := c.ListTokens(ctx, keys(projectIDsToNames), cmd.Name)
+	if err != nil {
+		return err
+	}
+
+	revoked := 0
+	for _, token := range tokens {
+		if err := c.RevokeToken(ctx, token); err != nil {
+			return err
+		}
+		fmt.Printf("revoked token %s of project %s\n", token.Name, projectIDsToNames[token.ProjectID])
+		revoked++
+	}
+
+	fmt.Printf("%d tokens revoked\n", revoked)
+	return nil
+}
+
+// tokensCockpit returns the Cockpit client of the profile, and the projects it can access.
+func tokensCockpit(ctx context.Context, rs *RootState) (*cockpit.Cockpit, map[string]string, error) {
+	p, err := loadScalewayProfile(rs.Profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profileName := rs.Profile
+	if profileName == "" {
+		profileName = "default"
+	}
+
+	client, err := newScalewayClient(rs, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projects, err := scaleway.ListProjects(ctx, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projectIDsToNames := make(map[string]string, len(projects))
+	for _, p := range projects {
+		metadata := p.Metadata()
+		projectIDsToNames[metadata.ID] = metadata.Name
+	}
+
+	return newCockpit(rs.Logger, client, rs.Config, profileName), projectIDsToNames, nil
+}
+
+func keys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -5,8 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/config"
 	"github.com/cyclimse/scwtui/internal/discovery"
 	demo_discovery "github.com/cyclimse/scwtui/internal/discovery/demo"
 	"github.com/cyclimse/scwtui/internal/discovery/scaleway"
@@ -34,7 +39,12 @@ func (cmd *TuiCmd) Run(rs *RootState) error {
 		return err
 	}
 
-	store, err := sqlite.NewStore(context.Background(), "scwtui.db")
+	profileName := rs.Profile
+	if profileName == "" {
+		profileName = "default"
+	}
+
+	store, err := openStore(context.Background(), logger, rs.Config.Store, profileName, cmd.Demo)
 	if err != nil {
 		return err
 	}
@@ -53,10 +63,7 @@ func (cmd *TuiCmd) Run(rs *RootState) error {
 		discoverer = demo_discovery.NewDiscovery(projects)
 		monitor = demo_monitor.NewDemo()
 	} else {
-		client, err = scw.NewClient(
-			scw.WithUserAgent(fmt.Sprintf("scwtui/%s", rs.Version)),
-			scw.WithProfile(p),
-		)
+		client, err = newScalewayClient(rs, p)
 		if err != nil {
 			return err
 		}
@@ -68,10 +75,12 @@ func (cmd *TuiCmd) Run(rs *RootState) error {
 
 		discoverer = scaleway.NewResourceDiscoverer(logger, client, projects, &scaleway.ResourceDiscovererConfig{
 			NumWorkers: 10,
-			MaxRetries: 3,
+			MaxRetries: 5,
+			MinBackoff: 1 * time.Second,
+			MaxBackoff: 30 * time.Second,
 		})
 
-		monitor = cockpit.NewCockpit(logger, client)
+		monitor = newCockpit(logger, client, rs.Config, profileName)
 	}
 
 	projectIDsToNames := make(map[string]string, len(projects))
@@ -85,6 +94,11 @@ func (cmd *TuiCmd) Run(rs *RootState) error {
 		return err
 	}
 
+	// Show the resources of the previous session while they are being rediscovered.
+	if err := loadPreviousSession(context.Background(), logger, store, search, projectIDsToNames); err != nil {
+		return err
+	}
+
 	for _, r := range projects {
 		if err := store.Store(context.Background(), r); err != nil {
 			logger.Error("tui: failed to store resource", slog.Any("resource", r), slog.String("err", err.Error()))
@@ -96,17 +110,17 @@ func (cmd *TuiCmd) Run(rs *RootState) error {
 		}
 	}
 
-	profileName := rs.Profile
-	if profileName == "" {
-		profileName = "default"
-	}
+	index := resource.NewIndex(store, search)
+	refresher := discovery.NewRefresher(logger, discoverer, store, index, rs.Config.Discovery.RefreshInterval)
 
 	appState := ui.ApplicationState{
 		Logger: logger,
 
 		Store:   store,
 		Search:  search,
+		Index:   index,
 		Monitor: monitor,
+		Sync:    refresher,
 
 		ScwClient:         client,
 		ScwProfileName:    profileName,
@@ -122,8 +136,11 @@ func (cmd *TuiCmd) Run(rs *RootState) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	g := start(ctx, logger, discoverer, resource.NewIndex(store, search))
+	g, runCtx := errgroup.WithContext(ctx)
 
+	g.Go(func() error {
+		return refresher.Run(runCtx)
+	})
 	g.Go(func() error {
 		if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
 			return err
@@ -142,43 +159,106 @@ func (cmd *TuiCmd) Run(rs *RootState) error {
 	return nil
 }
 
-const (
-	channelBufferSize = 100
-)
-
-func start(ctx context.Context, logger *slog.Logger, discoverer discovery.ResourceDiscoverer, index resource.Indexer) *errgroup.Group {
-	g, runCtx := errgroup.WithContext(ctx)
+func newScalewayClient(rs *RootState, p *scw.Profile) (*scw.Client, error) {
+	return scw.NewClient(
+		scw.WithUserAgent(fmt.Sprintf("scwtui/%s", rs.Version)),
+		scw.WithProfile(p),
+		scw.WithHTTPClient(scaleway.NewHTTPClient()),
+	)
+}
 
-	ch := make(chan resource.Resource, channelBufferSize)
+// newCockpit returns the Cockpit client, with the tokens named after the machine.
+func newCockpit(logger *slog.Logger, client *scw.Client, cfg config.Config, profileName string) *cockpit.Cockpit {
+	tokenName := cfg.Cockpit.TokenName
+	if tokenName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.Warn("tui: failed to get hostname", slog.String("err", err.Error()))
+		}
+		tokenName = cockpit.DefaultTokenName(hostname)
+	}
 
-	g.Go(func() error {
-		err := discoverer.Discover(runCtx, ch)
+	var tokenCachePath string
+	if cfg.Cockpit.CacheTokens {
+		dir, err := cacheDir(cfg.Store)
 		if err != nil {
-			if errors.Is(err, context.Canceled) {
-				return nil
-			}
-			logger.Error("tui: failed to discover resources", slog.String("err", err.Error()))
+			logger.Warn("tui: failed to find cache directory, tokens will not be cached", slog.String("err", err.Error()))
+		} else {
+			tokenCachePath = filepath.Join(dir, url.PathEscape(profileName)+"-tokens.json")
 		}
-		return err
+	}
+
+	return cockpit.NewCockpit(logger, client, &cockpit.Config{
+		TokenName:      tokenName,
+		TokenCachePath: tokenCachePath,
 	})
-	g.Go(func() error {
-		for {
-			select {
-			case <-runCtx.Done():
-				return runCtx.Err()
-			case r, ok := <-ch:
-				if !ok {
-					return nil
-				}
-				if err := index.Index(runCtx, r); err != nil {
-					logger.Error("tui: failed to index resource", slog.Any("resource", r), slog.String("err", err.Error()))
-					return err
-				}
+}
+
+// cacheDir returns the directory of the files kept between runs.
+func cacheDir(cfg config.Store) (string, error) {
+	if cfg.Dir != "" {
+		return cfg.Dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scwtui"), nil
+}
+
+// openStore opens the on-disk store of the profile, or an in-memory store if persistence is disabled.
+func openStore(ctx context.Context, logger *slog.Logger, cfg config.Store, profileName string, demo bool) (*sqlite.Store, error) {
+	// the demo resources are not worth keeping.
+	if demo || !cfg.Persist {
+		return sqlite.NewStore(ctx, "scwtui.db")
+	}
+
+	dir, err := cacheDir(cfg)
+	if err != nil {
+		logger.Warn("tui: failed to find cache directory, resources will not be persisted", slog.String("err", err.Error()))
+		return sqlite.NewStore(ctx, "scwtui.db")
+	}
+
+	path := filepath.Join(dir, url.PathEscape(profileName)+".db")
+
+	store, err := sqlite.NewPersistentStore(ctx, path)
+	if err != nil {
+		logger.Warn("tui: failed to open on-disk store, resources will not be persisted",
+			slog.String("path", path),
+			slog.String("err", err.Error()))
+		return sqlite.NewStore(ctx, "scwtui.db")
+	}
+
+	return store, nil
+}
+
+// loadPreviousSession indexes the resources found in the store.
+// The resources of projects that no longer exist are removed, as they will not be rediscovered.
+func loadPreviousSession(ctx context.Context, logger *slog.Logger, store *sqlite.Store, search *bleve.Search, projectIDsToNames map[string]string) error {
+	resources, err := store.ListAllResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resources {
+		metadata := r.Metadata()
+
+		if _, ok := projectIDsToNames[metadata.ProjectID]; metadata.ProjectID != "" && !ok {
+			if err := store.DeleteResource(ctx, r); err != nil {
+				logger.Error("tui: failed to delete resource", slog.String("id", metadata.ID), slog.String("err", err.Error()))
 			}
+			continue
 		}
-	})
 
-	return g
+		if err := search.Index(r); err != nil {
+			logger.Error("tui: failed to index resource", slog.Any("resource", r), slog.String("err", err.Error()))
+			return err
+		}
+	}
+
+	logger.Info("tui: loaded resources from previous session", slog.Int("num_resources", len(resources)))
+
+	return nil
 }
 
 func loadScalewayProfile(profileName string) (*scw.Profile, error) {
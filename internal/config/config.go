@@ -1,6 +1,9 @@
 package config
 
-import "log/slog"
+import (
+	"log/slog"
+	"time"
+)
 
 type Config struct {
 	Logging struct {
@@ -10,14 +13,31 @@ type Config struct {
 
 	Debug bool `default:"false" help:"Enable debug mode"`
 
-	Scaleway `embed:""`
-	Tui      `embed:"" prefix:"ui-"`
+	Scaleway  `embed:""`
+	Discovery `embed:"" prefix:"discovery-"`
+	Store     `embed:"" prefix:"store-"`
+	Cockpit   `embed:"" prefix:"cockpit-"`
+	Tui       `embed:"" prefix:"ui-"`
 }
 
 type Scaleway struct {
 	Profile string `default:"" help:"Scaleway profile name"`
 }
 
+type Discovery struct {
+	RefreshInterval time.Duration `default:"5m" help:"Interval between two discoveries of the resources. Set to 0 to only discover them once."`
+}
+
+type Store struct {
+	Persist bool   `default:"true" help:"Keep the discovered resources on disk to show them on the next launch." negatable:""`
+	Dir     string `default:""     help:"Directory of the on-disk store. Defaults to the user cache directory."`
+}
+
+type Cockpit struct {
+	TokenName   string `default:""      help:"Name of the Cockpit tokens created by scwtui. Defaults to scwtui-<hostname>."`
+	CacheTokens bool   `default:"false" help:"Keep the secret keys of the Cockpit tokens in the keyring of the OS, to reuse them on the next launch." negatable:""`
+}
+
 type Tui struct {
 	Theme string `default:"monokai" help:"The theme to use for syntax highlighting."`
 }
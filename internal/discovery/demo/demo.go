@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
+	"github.com/cyclimse/scwtui/internal/discovery"
 	"github.com/cyclimse/scwtui/internal/resource"
 	"github.com/cyclimse/scwtui/internal/resource/demo"
 	"github.com/scaleway/scaleway-sdk-go/namegenerator"
@@ -78,17 +79,68 @@ type Discovery struct {
 	allStatuses              []resource.Status
 	fakeProjects             []resource.Resource
 	countResourcesToGenerate int
+
+	// generated are the resources generated during the first discovery.
+	generated []resource.Resource
 }
 
-func (d *Discovery) Discover(_ context.Context, ch chan resource.Resource) error {
+func (d *Discovery) Discover(ctx context.Context, ch chan resource.Resource, reporter discovery.Reporter) ([]discovery.Scope, error) {
+	reporter.Start(1)
+
+	// subsequent discoveries return the same resources,
+	// otherwise the whole table would be replaced on every refresh.
+	if d.generated != nil {
+		for _, r := range d.generated {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case ch <- r:
+			}
+		}
+		reporter.Report(d.event())
+		return d.scopes(), nil
+	}
+
+	generated := make([]resource.Resource, 0, d.countResourcesToGenerate)
 	for i := 0; i < d.countResourcesToGenerate; i++ {
-		ch <- d.resource()
+		r := d.resource()
+		generated = append(generated, r)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case ch <- r:
+		}
 
 		// sleep a bit to simulate a real discovery
 		time.Sleep(200 * time.Millisecond)
 	}
+	d.generated = generated
+
+	reporter.Report(d.event())
+	return d.scopes(), nil
+}
+
+func (d *Discovery) event() discovery.Event {
+	return discovery.Event{
+		Product:  "Demo",
+		Locality: resource.Global,
+		Count:    len(d.generated),
+		Time:     time.Now(),
+	}
+}
+
+// scopes returns a single scope covering every type of resource, except for the projects
+// which are not discovered.
+func (d *Discovery) scopes() []discovery.Scope {
+	types := make([]resource.Type, 0, resource.NumberOfResourceTypes)
+	for t := resource.Type(0); t < resource.NumberOfResourceTypes; t++ {
+		if t != resource.TypeProject {
+			types = append(types, t)
+		}
+	}
 
-	return nil
+	return []discovery.Scope{{Types: types}}
 }
 
 func (d *Discovery) resource() resource.Resource {
@@ -4,9 +4,67 @@ import (
 	"context"
 
 	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 type ResourceDiscoverer interface {
 	// Discover discovers resources and sends them to the given channel.
-	Discover(ctx context.Context, r chan resource.Resource) error
+	// The progress of the discovery is published to the reporter.
+	// It returns the scopes that were fully discovered. Resources in those scopes
+	// that were not sent to the channel can be considered as deleted.
+	Discover(ctx context.Context, r chan resource.Resource, reporter Reporter) ([]Scope, error)
+}
+
+// Scope is a set of resource types discovered in a given locality.
+type Scope struct {
+	// Types are the types of resources covered by the scope.
+	Types []resource.Type
+
+	// Locality is the locality covered by the scope.
+	// A region also covers its zones, e.g. the pools of the Kapsule clusters of the region.
+	// If nil, the scope covers all localities.
+	Locality resource.Locality
+
+	// ProjectID is the project covered by the scope.
+	// If empty, the scope covers all projects.
+	ProjectID string
+}
+
+// Covers returns true if the resource is part of the scope.
+func (s Scope) Covers(r resource.Resource) bool {
+	metadata := r.Metadata()
+
+	if s.Locality != nil && !covers(s.Locality, metadata.Locality) {
+		return false
+	}
+
+	if s.ProjectID != "" && metadata.ProjectID != s.ProjectID {
+		return false
+	}
+
+	for _, t := range s.Types {
+		if t == metadata.Type {
+			return true
+		}
+	}
+
+	return false
+}
+
+// covers returns true if the locality is the scope locality, or one of its zones.
+func covers(scope, locality resource.Locality) bool {
+	if locality == nil {
+		return false
+	}
+
+	if locality.String() == scope.String() {
+		return true
+	}
+
+	if !scope.IsRegion() || !locality.IsZone() {
+		return false
+	}
+
+	region, err := scw.Zone(locality.String()).Region()
+	return err == nil && region.String() == scope.String()
 }
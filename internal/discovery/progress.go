@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+// Event is published by a discoverer when a request for resources has finished.
+type Event struct {
+	// Product is the name of the product that was queried.
+	Product string
+
+	// Locality is the locality that was queried.
+	Locality resource.Locality
+
+	// ProjectID is the project that was queried.
+	// Empty if the request was not scoped to a project.
+	ProjectID string
+
+	// Count is the number of resources that were discovered.
+	Count int
+
+	// Err is the error that made the request fail, if any.
+	Err error
+
+	// Hint is a human-friendly explanation of the error.
+	// May be empty.
+	Hint string
+
+	// Time is the time at which the request finished.
+	Time time.Time
+}
+
+// Reporter receives the progress of a discovery.
+type Reporter interface {
+	// Start is called when a discovery starts with the number of requests it will make.
+	Start(total int)
+
+	// Report is called when a request has finished.
+	Report(e Event)
+}
+
+// Status is a snapshot of the progress of the discoveries.
+type Status struct {
+	// Running is true while a discovery is in progress.
+	Running bool
+
+	// Done is the number of requests that have finished during the current
+	// or the last discovery.
+	Done int
+
+	// Total is the number of requests of the current or the last discovery.
+	Total int
+
+	// Events holds the last event for each product, locality and project.
+	// Failures are sorted first.
+	Events []Event
+}
+
+// Failures returns the events of the requests that failed.
+func (s Status) Failures() []Event {
+	failures := make([]Event, 0)
+	for _, e := range s.Events {
+		if e.Err != nil {
+			failures = append(failures, e)
+		}
+	}
+	return failures
+}
+
+// Count returns the number of resources discovered.
+func (s Status) Count() int {
+	count := 0
+	for _, e := range s.Events {
+		count += e.Count
+	}
+	return count
+}
+
+func newProgress() *progress {
+	return &progress{
+		mutex:  &sync.RWMutex{},
+		events: make(map[eventKey]Event),
+	}
+}
+
+// progress implements Reporter.
+// The events of the previous discoveries are kept until they are replaced,
+// so that failures stay visible while a new discovery is running.
+type progress struct {
+	mutex   *sync.RWMutex
+	running bool
+	done    int
+	total   int
+	events  map[eventKey]Event
+}
+
+type eventKey struct {
+	Product   string
+	Locality  string
+	ProjectID string
+}
+
+// Start implements Reporter.
+func (p *progress) Start(total int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.running = true
+	p.done = 0
+	p.total = total
+}
+
+// Report implements Reporter.
+func (p *progress) Report(e Event) {
+	locality := ""
+	if e.Locality != nil {
+		locality = e.Locality.String()
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.done++
+	p.events[eventKey{Product: e.Product, Locality: locality, ProjectID: e.ProjectID}] = e
+}
+
+func (p *progress) finish() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.running = false
+}
+
+func (p *progress) status() Status {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	events := make([]Event, 0, len(p.events))
+	for _, e := range p.events {
+		events = append(events, e)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if (a.Err != nil) != (b.Err != nil) {
+			return a.Err != nil
+		}
+		if a.Product != b.Product {
+			return a.Product < b.Product
+		}
+		if a.Locality != nil && b.Locality != nil && a.Locality.String() != b.Locality.String() {
+			return a.Locality.String() < b.Locality.String()
+		}
+		return a.ProjectID < b.ProjectID
+	})
+
+	return Status{
+		Running: p.running,
+		Done:    p.done,
+		Total:   p.total,
+		Events:  events,
+	}
+}
@@ -0,0 +1,184 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	channelBufferSize = 100
+)
+
+// Syncer reports when and how the resources were last synchronized with the API.
+type Syncer interface {
+	// LastSynced returns the time at which the last discovery finished.
+	// It returns the zero time if no discovery finished yet.
+	LastSynced() time.Time
+
+	// Status returns the progress of the discoveries.
+	Status() Status
+}
+
+// NewRefresher creates a new refresher.
+// If interval is zero, the resources are only discovered once.
+func NewRefresher(logger *slog.Logger, discoverer ResourceDiscoverer, store resource.Storer, index resource.Indexer, interval time.Duration) *Refresher {
+	return &Refresher{
+		logger:     logger,
+		discoverer: discoverer,
+		store:      store,
+		index:      index,
+		interval:   interval,
+		progress:   newProgress(),
+		mutex:      &sync.RWMutex{},
+	}
+}
+
+// Refresher periodically discovers resources and removes the ones that were deleted
+// from the index.
+type Refresher struct {
+	logger     *slog.Logger
+	discoverer ResourceDiscoverer
+	store      resource.Storer
+	index      resource.Indexer
+	interval   time.Duration
+	progress   *progress
+
+	mutex      *sync.RWMutex
+	lastSynced time.Time
+}
+
+// Run discovers resources every interval until the context is canceled.
+func (r *Refresher) Run(ctx context.Context) error {
+	for {
+		err := r.Refresh(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			r.logger.Error("refresher: failed to refresh resources", slog.String("err", err.Error()))
+		}
+
+		if r.interval <= 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// Refresh runs a single discovery and reconciles its results with the store.
+func (r *Refresher) Refresh(ctx context.Context) error {
+	// Only the resources that were present before the discovery started are
+	// candidates for removal. This avoids removing resources created in the meantime,
+	// for instance by an action.
+	before, err := r.store.ListAllResources(ctx)
+	if err != nil {
+		return fmt.Errorf("refresher: failed to list resources: %w", err)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	ch := make(chan resource.Resource, channelBufferSize)
+	seen := make(map[resourceKey]struct{})
+
+	var scopes []Scope
+
+	g.Go(func() error {
+		defer close(ch)
+
+		var err error
+		scopes, err = r.discoverer.Discover(gctx, ch, r.progress)
+		return err
+	})
+	g.Go(func() error {
+		for res := range ch {
+			if err := r.index.Index(gctx, res); err != nil {
+				return err
+			}
+			seen[keyOf(res)] = struct{}{}
+		}
+		return nil
+	})
+
+	err = g.Wait()
+	r.progress.finish()
+	if err != nil {
+		return err
+	}
+
+	r.reconcile(ctx, before, seen, scopes)
+
+	r.mutex.Lock()
+	r.lastSynced = time.Now()
+	r.mutex.Unlock()
+
+	return nil
+}
+
+// reconcile deindexes the resources that were not seen again in a scope that was fully discovered.
+func (r *Refresher) reconcile(ctx context.Context, before []resource.Resource, seen map[resourceKey]struct{}, scopes []Scope) {
+	for _, res := range before {
+		if _, ok := seen[keyOf(res)]; ok {
+			continue
+		}
+
+		if !anyCovers(scopes, res) {
+			continue
+		}
+
+		metadata := res.Metadata()
+		r.logger.Info("refresher: resource was deleted",
+			slog.String("id", metadata.ID),
+			slog.String("type", metadata.Type.String()))
+
+		if err := r.index.Deindex(ctx, res); err != nil && !errors.Is(err, resource.ErrResourceNotFound) {
+			r.logger.Error("refresher: failed to deindex resource",
+				slog.String("id", metadata.ID),
+				slog.String("err", err.Error()))
+		}
+	}
+}
+
+// LastSynced implements Syncer.
+func (r *Refresher) LastSynced() time.Time {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.lastSynced
+}
+
+// Status implements Syncer.
+func (r *Refresher) Status() Status {
+	return r.progress.status()
+}
+
+// resourceKey identifies a resource in the store.
+// Some resources share the same id but have a different type.
+type resourceKey struct {
+	ID   string
+	Type resource.Type
+}
+
+func keyOf(r resource.Resource) resourceKey {
+	metadata := r.Metadata()
+	return resourceKey{ID: metadata.ID, Type: metadata.Type}
+}
+
+func anyCovers(scopes []Scope, r resource.Resource) bool {
+	for _, s := range scopes {
+		if s.Covers(r) {
+			return true
+		}
+	}
+	return false
+}
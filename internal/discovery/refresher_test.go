@@ -0,0 +1,150 @@
+package discovery_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/cyclimse/scwtui/internal/discovery"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	"github.com/cyclimse/scwtui/internal/search/bleve"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDiscoverer struct {
+	resources []resource.Resource
+	scopes    []discovery.Scope
+}
+
+func (d *fakeDiscoverer) Discover(_ context.Context, ch chan resource.Resource, reporter discovery.Reporter) ([]discovery.Scope, error) {
+	reporter.Start(1)
+	for _, r := range d.resources {
+		ch <- r
+	}
+	reporter.Report(discovery.Event{Product: "Fake", Count: len(d.resources)})
+	return d.scopes, nil
+}
+
+func registryNamespace(id string, region scw.Region) scaleway.RegistryNamespace {
+	return scaleway.RegistryNamespace{
+		ID:     id,
+		Region: region,
+	}
+}
+
+func TestRefresher_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	project := scaleway.Project{ID: "project"}
+	kept := registryNamespace("kept", scw.RegionFrPar)
+	deleted := registryNamespace("deleted", scw.RegionFrPar)
+	notCovered := registryNamespace("not-covered", scw.RegionNlAms)
+
+	store := testhelpers.NewStoreFromResources(t, []resource.Resource{project, kept, deleted, notCovered})
+	defer store.Close()
+
+	search, err := bleve.NewSearch(nil)
+	require.NoError(t, err)
+
+	discoverer := &fakeDiscoverer{
+		resources: []resource.Resource{kept},
+		scopes: []discovery.Scope{
+			{Types: []resource.Type{resource.TypeRegistryNamespace}, Locality: resource.Region(scw.RegionFrPar)},
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	refresher := discovery.NewRefresher(logger, discoverer, store, resource.NewIndex(store, search), 0)
+	assert.True(t, refresher.LastSynced().IsZero())
+
+	err = refresher.Refresh(ctx)
+	require.NoError(t, err)
+	assert.False(t, refresher.LastSynced().IsZero())
+
+	status := refresher.Status()
+	assert.False(t, status.Running)
+	assert.Equal(t, 1, status.Done)
+	assert.Equal(t, 1, status.Count())
+
+	for _, r := range []resource.Resource{project, kept, notCovered} {
+		_, err := store.GetResource(ctx, r.Metadata().ID)
+		require.NoError(t, err, "resource %s should have been kept", r.Metadata().ID)
+	}
+
+	_, err = store.GetResource(ctx, deleted.Metadata().ID)
+	require.ErrorIs(t, err, resource.ErrResourceNotFound)
+}
+
+func TestRefresher_ZonalResourcesInRegion(t *testing.T) {
+	ctx := context.Background()
+
+	kept := scaleway.PublicGateway(vpcgw.Gateway{ID: "kept", ProjectID: "project", Zone: scw.ZoneFrPar1})
+	deleted := scaleway.PublicGateway(vpcgw.Gateway{ID: "deleted", ProjectID: "project", Zone: scw.ZoneFrPar2})
+	notCovered := scaleway.PublicGateway(vpcgw.Gateway{ID: "not-covered", ProjectID: "project", Zone: scw.ZoneNlAms1})
+
+	store := testhelpers.NewStoreFromResources(t, []resource.Resource{kept, deleted, notCovered})
+	defer store.Close()
+
+	search, err := bleve.NewSearch(nil)
+	require.NoError(t, err)
+
+	discoverer := &fakeDiscoverer{
+		resources: []resource.Resource{kept},
+		scopes: []discovery.Scope{
+			{Types: []resource.Type{resource.TypeVPC, resource.TypePrivateNetwork, resource.TypePublicGateway}, Locality: resource.Region(scw.RegionFrPar)},
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	refresher := discovery.NewRefresher(logger, discoverer, store, resource.NewIndex(store, search), 0)
+	require.NoError(t, refresher.Refresh(ctx))
+
+	for _, r := range []resource.Resource{kept, notCovered} {
+		_, err := store.GetResource(ctx, r.Metadata().ID)
+		require.NoError(t, err, "resource %s should have been kept", r.Metadata().ID)
+	}
+
+	_, err = store.GetResource(ctx, deleted.Metadata().ID)
+	require.ErrorIs(t, err, resource.ErrResourceNotFound, "the zonal resources of a region should be reconciled")
+}
+
+func TestRefresher_DeletedKapsulePool(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := k8s.Cluster{ID: "cluster", ProjectID: "project", Region: scw.RegionFrPar}
+	kept := scaleway.KapsulePool{Pool: k8s.Pool{ID: "kept", ClusterID: cluster.ID, Region: cluster.Region, Zone: scw.ZoneFrPar1}, Cluster: cluster}
+	deleted := scaleway.KapsulePool{Pool: k8s.Pool{ID: "deleted", ClusterID: cluster.ID, Region: cluster.Region, Zone: scw.ZoneFrPar2}, Cluster: cluster}
+	deletedNode := scaleway.KapsuleNode{Node: k8s.Node{ID: "deleted-node", PoolID: deleted.Pool.ID, Region: cluster.Region}, Pool: deleted.Pool, Cluster: cluster}
+
+	store := testhelpers.NewStoreFromResources(t, []resource.Resource{kept, deleted, deletedNode})
+	defer store.Close()
+
+	search, err := bleve.NewSearch(nil)
+	require.NoError(t, err)
+
+	discoverer := &fakeDiscoverer{
+		resources: []resource.Resource{kept},
+		scopes: []discovery.Scope{
+			{Types: []resource.Type{resource.TypeKapsuleCluster, resource.TypeKapsulePool, resource.TypeKapsuleNode}, Locality: resource.Region(scw.RegionFrPar)},
+		},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	refresher := discovery.NewRefresher(logger, discoverer, store, resource.NewIndex(store, search), 0)
+	require.NoError(t, refresher.Refresh(ctx))
+
+	_, err = store.GetResource(ctx, kept.Metadata().ID)
+	require.NoError(t, err)
+
+	for _, r := range []resource.Resource{deleted, deletedNode} {
+		_, err := store.GetResource(ctx, r.Metadata().ID)
+		require.ErrorIs(t, err, resource.ErrResourceNotFound, "resource %s should have been deleted", r.Metadata().ID)
+	}
+}
@@ -0,0 +1,63 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cyclimse/scwtui/internal/objectstorage"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func (d *ResourceDiscover) discoverBucketsInRegion(ctx context.Context, region scw.Region, projectID string) ([]resource.Resource, error) {
+	endpoint := d.config.ObjectStorageEndpoint
+	if endpoint == "" {
+		endpoint = objectstorage.Endpoint(region)
+	}
+
+	client, err := objectstorage.NewClient(d.client, endpoint, region, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := client.ListBuckets(ctx)
+	if err = handleObjectStorageError(err); err != nil {
+		return nil, err
+	}
+
+	resources := make([]resource.Resource, 0, len(buckets))
+	for _, bucket := range buckets {
+		createdAt := bucket.CreatedAt
+		resources = append(resources, scaleway.Bucket{
+			Name:      bucket.Name,
+			ProjectID: projectID,
+			Region:    region,
+			CreatedAt: &createdAt,
+			Endpoint:  endpoint,
+		})
+	}
+
+	return resources, nil
+}
+
+// handleObjectStorageError is the counterpart of handleRequestError for the S3 API, which is not part of the SDK.
+func handleObjectStorageError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if status := objectstorage.StatusCode(err); status == http.StatusTooManyRequests || status >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: %w", ErrShouldRetry, err)
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%w: %w", ErrShouldRetry, err)
+	}
+
+	return err
+}
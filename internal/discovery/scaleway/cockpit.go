@@ -9,25 +9,19 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
-func (d *ResourceDiscover) discoverCockpits(ctx context.Context) ([]resource.Resource, error) {
+func (d *ResourceDiscover) discoverCockpitInProject(ctx context.Context, projectID string) ([]resource.Resource, error) {
 	api := sdk.NewAPI(d.client)
 
-	cockpits := make([]resource.Resource, 0, len(d.projects))
-
-	for _, project := range d.projects {
-		cockpit, err := api.GetCockpit(&sdk.GetCockpitRequest{
-			ProjectID: project.Metadata().ID,
-		}, scw.WithContext(ctx))
-		if handleRequestError(err) != nil {
-			return nil, err
-		}
-
-		if cockpit == nil {
-			continue
-		}
+	cockpit, err := api.GetCockpit(&sdk.GetCockpitRequest{
+		ProjectID: projectID,
+	}, scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
 
-		cockpits = append(cockpits, scaleway.Cockpit(*cockpit))
+	if cockpit == nil {
+		return nil, nil
 	}
 
-	return cockpits, nil
+	return []resource.Resource{scaleway.Cockpit(*cockpit)}, nil
 }
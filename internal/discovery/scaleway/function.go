@@ -9,47 +9,43 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
-func (d *ResourceDiscover) discoverFunctionsInRegion(ctx context.Context, region scw.Region) ([]resource.Resource, error) {
+func (d *ResourceDiscover) discoverFunctionsInRegion(ctx context.Context, region scw.Region, projectID string) ([]resource.Resource, error) {
 	api := sdk.NewAPI(d.client)
 
 	resources := make([]resource.Resource, 0)
 
-	for _, project := range d.projects {
-		projectID := project.Metadata().ID
+	nss, err := api.ListNamespaces(&sdk.ListNamespacesRequest{
+		Region:    region,
+		ProjectID: &projectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	for _, ns := range nss.Namespaces {
+		if ns == nil {
+			continue
+		}
 
-		nss, err := api.ListNamespaces(&sdk.ListNamespacesRequest{
-			Region:    region,
-			ProjectID: &projectID,
+		resources = append(resources, scaleway.FunctionNamespace(*ns))
+
+		fs, err := api.ListFunctions(&sdk.ListFunctionsRequest{
+			Region:      region,
+			NamespaceID: ns.ID,
 		}, scw.WithAllPages(), scw.WithContext(ctx))
-		if handleRequestError(err) != nil {
+		if err = handleRequestError(err); err != nil {
 			return nil, err
 		}
 
-		for _, ns := range nss.Namespaces {
-			if ns == nil {
+		for _, f := range fs.Functions {
+			if f == nil {
 				continue
 			}
 
-			resources = append(resources, scaleway.FunctionNamespace(*ns))
-
-			fs, err := api.ListFunctions(&sdk.ListFunctionsRequest{
-				Region:      region,
-				NamespaceID: ns.ID,
-			}, scw.WithAllPages(), scw.WithContext(ctx))
-			if handleRequestError(err) != nil {
-				return nil, err
-			}
-
-			for _, f := range fs.Functions {
-				if f == nil {
-					continue
-				}
-
-				resources = append(resources, scaleway.Function{
-					Function:  *f,
-					Namespace: *ns,
-				})
-			}
+			resources = append(resources, scaleway.Function{
+				Function:  *f,
+				Namespace: *ns,
+			})
 		}
 	}
 
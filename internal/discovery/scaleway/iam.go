@@ -20,7 +20,7 @@ func (d *ResourceDiscover) discoverIAMApplications(ctx context.Context) ([]resou
 	apps, err := api.ListApplications(&iam.ListApplicationsRequest{
 		OrganizationID: organizationID,
 	}, scw.WithAllPages(), scw.WithContext(ctx))
-	if err != nil {
+	if err = handleRequestError(err); err != nil {
 		return nil, err
 	}
 
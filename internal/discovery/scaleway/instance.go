@@ -15,7 +15,7 @@ func (d *ResourceDiscover) discoverInstancesInZone(ctx context.Context, zone scw
 	servers, err := api.ListServers(&sdk.ListServersRequest{
 		Zone: zone,
 	}, scw.WithAllPages(), scw.WithContext(ctx))
-	if handleRequestError(err) != nil {
+	if err = handleRequestError(err); err != nil {
 		return nil, err
 	}
 
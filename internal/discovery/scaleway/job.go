@@ -15,7 +15,7 @@ func (d *ResourceDiscover) discoverJobsInRegion(ctx context.Context, region scw.
 	jobs, err := api.ListJobDefinitions(&sdk.ListJobDefinitionsRequest{
 		Region: region,
 	}, scw.WithAllPages(), scw.WithContext(ctx))
-	if handleRequestError(err) != nil {
+	if err = handleRequestError(err); err != nil {
 		return nil, err
 	}
 
@@ -32,7 +32,7 @@ func (d *ResourceDiscover) discoverJobsInRegion(ctx context.Context, region scw.
 			Region:          region,
 			JobDefinitionID: &jobDef.ID,
 		}, scw.WithAllPages(), scw.WithContext(ctx))
-		if handleRequestError(err) != nil {
+		if err = handleRequestError(err); err != nil {
 			return nil, err
 		}
 
@@ -9,13 +9,14 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// discoverKapsuleClustersInRegion discovers the clusters of the region, with their pools and nodes.
 func (d *ResourceDiscover) discoverKapsuleClustersInRegion(ctx context.Context, region scw.Region) ([]resource.Resource, error) {
 	api := sdk.NewAPI(d.client)
 
 	clusters, err := api.ListClusters(&sdk.ListClustersRequest{
 		Region: region,
 	}, scw.WithAllPages(), scw.WithContext(ctx))
-	if handleRequestError(err) != nil {
+	if err = handleRequestError(err); err != nil {
 		return nil, err
 	}
 
@@ -27,6 +28,46 @@ func (d *ResourceDiscover) discoverKapsuleClustersInRegion(ctx context.Context,
 		}
 
 		resources = append(resources, scaleway.KapsuleCluster(*cluster))
+
+		pools, err := api.ListPools(&sdk.ListPoolsRequest{
+			Region:    region,
+			ClusterID: cluster.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return nil, err
+		}
+
+		// the nodes of all the pools are listed at once.
+		nodes, err := api.ListNodes(&sdk.ListNodesRequest{
+			Region:    region,
+			ClusterID: cluster.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return nil, err
+		}
+
+		for _, pool := range pools.Pools {
+			if pool == nil {
+				continue
+			}
+
+			resources = append(resources, scaleway.KapsulePool{
+				Pool:    *pool,
+				Cluster: *cluster,
+			})
+
+			for _, node := range nodes.Nodes {
+				if node == nil || node.PoolID != pool.ID {
+					continue
+				}
+
+				resources = append(resources, scaleway.KapsuleNode{
+					Node:    *node,
+					Pool:    *pool,
+					Cluster: *cluster,
+				})
+			}
+		}
 	}
 
 	return resources, nil
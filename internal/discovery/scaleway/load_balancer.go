@@ -0,0 +1,66 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func (d *ResourceDiscover) discoverLoadBalancersInZone(ctx context.Context, zone scw.Zone) ([]resource.Resource, error) {
+	api := sdk.NewZonedAPI(d.client)
+
+	lbs, err := api.ListLBs(&sdk.ZonedAPIListLBsRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	resources := make([]resource.Resource, 0, len(lbs.LBs))
+
+	for _, lb := range lbs.LBs {
+		if lb == nil {
+			continue
+		}
+
+		resources = append(resources, scaleway.LoadBalancer(*lb))
+
+		frontends, err := api.ListFrontends(&sdk.ZonedAPIListFrontendsRequest{
+			Zone: zone,
+			LBID: lb.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return nil, err
+		}
+
+		for _, frontend := range frontends.Frontends {
+			if frontend == nil {
+				continue
+			}
+			// the Load Balancer is needed to find the project and the zone of the frontend.
+			frontend.LB = lb
+			resources = append(resources, scaleway.LBFrontend(*frontend))
+		}
+
+		backends, err := api.ListBackends(&sdk.ZonedAPIListBackendsRequest{
+			Zone: zone,
+			LBID: lb.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return nil, err
+		}
+
+		for _, backend := range backends.Backends {
+			if backend == nil {
+				continue
+			}
+			backend.LB = lb
+			resources = append(resources, scaleway.LBBackend(*backend))
+		}
+	}
+
+	return resources, nil
+}
@@ -13,7 +13,7 @@ func ListProjects(ctx context.Context, client *scw.Client) ([]resource.Resource,
 	api := sdk.NewProjectAPI(client)
 
 	projects, err := api.ListProjects(&sdk.ProjectAPIListProjectsRequest{}, scw.WithAllPages(), scw.WithContext(ctx))
-	if handleRequestError(err) != nil {
+	if err = handleRequestError(err); err != nil {
 		return nil, err
 	}
 
@@ -9,29 +9,25 @@ import (
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
-func (d *ResourceDiscover) discoverRdbInstancesInRegion(ctx context.Context, region scw.Region) ([]resource.Resource, error) {
+func (d *ResourceDiscover) discoverRdbInstancesInRegion(ctx context.Context, region scw.Region, projectID string) ([]resource.Resource, error) {
 	api := sdk.NewAPI(d.client)
 
-	resources := make([]resource.Resource, 0)
+	instances, err := api.ListInstances(&sdk.ListInstancesRequest{
+		Region:    region,
+		ProjectID: &projectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
 
-	for _, project := range d.projects {
-		projectID := project.Metadata().ID
+	resources := make([]resource.Resource, 0, len(instances.Instances))
 
-		instances, err := api.ListInstances(&sdk.ListInstancesRequest{
-			Region:    region,
-			ProjectID: &projectID,
-		}, scw.WithAllPages(), scw.WithContext(ctx))
-		if handleRequestError(err) != nil {
-			return nil, err
+	for _, i := range instances.Instances {
+		if i == nil {
+			continue
 		}
 
-		for _, i := range instances.Instances {
-			if i == nil {
-				continue
-			}
-
-			resources = append(resources, scaleway.RdbInstance(*i))
-		}
+		resources = append(resources, scaleway.RdbInstance(*i))
 	}
 
 	return resources, nil
@@ -15,7 +15,7 @@ func (d *ResourceDiscover) discoverRegistryNamespacesInRegion(ctx context.Contex
 	nss, err := api.ListNamespaces(&registry.ListNamespacesRequest{
 		Region: region,
 	}, scw.WithAllPages(), scw.WithContext(ctx))
-	if handleRequestError(err) != nil {
+	if err = handleRequestError(err); err != nil {
 		return nil, err
 	}
 
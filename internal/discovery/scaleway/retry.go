@@ -0,0 +1,99 @@
+package scaleway
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// NewHTTPClient returns an HTTP client to use with the Scaleway SDK.
+// The SDK does not expose the headers of the responses in its errors, so this client
+// records the Retry-After header to let the discovery honor it.
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &retryAfterTransport{next: http.DefaultTransport},
+	}
+}
+
+type retryAfterTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if hint, ok := req.Context().Value(retryHintKey{}).(*retryHint); ok {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			hint.after = after
+		}
+	}
+
+	return resp, nil
+}
+
+type retryHintKey struct{}
+
+// retryHint holds the delay requested by the API before retrying a request.
+type retryHint struct {
+	after time.Duration
+}
+
+func withRetryHint(ctx context.Context, hint *retryHint) context.Context {
+	return context.WithValue(ctx, retryHintKey{}, hint)
+}
+
+// parseRetryAfter parses the value of a Retry-After header.
+// It can either be a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	after := date.Sub(now)
+	if after < 0 {
+		after = 0
+	}
+
+	return after, true
+}
+
+// backoff returns the delay to wait before the given retry.
+// The delay grows exponentially with the number of retries and is jittered
+// to avoid all the workers retrying at the same time.
+// The delay requested by the API is honored, up to the maximum backoff, so that a worker is never held for too long.
+func (c *ResourceDiscovererConfig) backoff(retry int, hint *retryHint) time.Duration {
+	if hint != nil && hint.after > 0 {
+		return min(hint.after, c.MaxBackoff)
+	}
+
+	delay := c.MaxBackoff
+	if d := c.MinBackoff << retry; retry < 32 && d > 0 && d < c.MaxBackoff {
+		delay = d
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	//nolint:gosec // no need for a cryptographically secure random number
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
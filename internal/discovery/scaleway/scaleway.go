@@ -3,10 +3,18 @@ package scaleway
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
 
+	"github.com/cyclimse/scwtui/internal/discovery"
+	"github.com/cyclimse/scwtui/internal/objectstorage"
 	"github.com/cyclimse/scwtui/internal/resource"
+	lb "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"golang.org/x/sync/errgroup"
 )
@@ -16,13 +24,12 @@ var ErrShouldRetry = errors.New("should retry")
 
 func NewResourceDiscoverer(logger *slog.Logger, client *scw.Client, projects []resource.Resource, config *ResourceDiscovererConfig) *ResourceDiscover {
 	return &ResourceDiscover{
-		logger:    logger,
-		client:    client,
-		config:    config,
-		projects:  projects,
-		requested: make(chan requestResources, 100),
-		regions:   discoveryRegions(logger, client),
-		zones:     discoveryZones(logger, client),
+		logger:   logger,
+		client:   client,
+		config:   config,
+		projects: projects,
+		regions:  discoveryRegions(logger, client),
+		zones:    discoveryZones(logger, client),
 	}
 }
 
@@ -32,8 +39,7 @@ type ResourceDiscover struct {
 	client *scw.Client
 	config *ResourceDiscovererConfig
 
-	projects  []resource.Resource
-	requested chan requestResources
+	projects []resource.Resource
 
 	regions []scw.Region
 	zones   []scw.Zone
@@ -41,54 +47,160 @@ type ResourceDiscover struct {
 
 type ResourceDiscovererConfig struct {
 	NumWorkers int
+
+	// MaxRetries is the maximum number of times a failing request is retried.
 	MaxRetries int
+	// MinBackoff is the delay before the first retry. It doubles on each retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between two retries.
+	MaxBackoff time.Duration
+
+	// ObjectStorageEndpoint replaces the endpoint of Object Storage in every region, such as for a S3-compatible stand-in.
+	ObjectStorageEndpoint string
 }
 
-func (d *ResourceDiscover) Discover(ctx context.Context, ch chan resource.Resource) error {
+func (d *ResourceDiscover) Discover(ctx context.Context, ch chan resource.Resource, reporter discovery.Reporter) ([]discovery.Scope, error) {
 	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(d.config.NumWorkers)
 
+	// Request resources
+	requests := d.requests()
+	reporter.Start(len(requests))
+
+	requested := make(chan requestResources, len(requests))
+	for _, req := range requests {
+		requested <- req
+	}
+
+	pass := newDiscoveryPass(requested, ch, reporter, len(requests))
+
 	// Discover resources
 	for i := 0; i < d.config.NumWorkers; i++ {
 		g.Go(func() error {
-			return d.runWorker(ctx, ch)
+			return d.runWorker(ctx, pass)
 		})
 	}
 
-	// Request resources
-	d.requested <- requestResources{
-		Get: d.discoverCockpits,
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return pass.completed, nil
+}
+
+const (
+	productCockpit    = "Cockpit"
+	productIAM        = "IAM"
+	productRegistry   = "Registry"
+	productContainers = "Serverless Containers"
+	productFunctions  = "Serverless Functions"
+	productJobs       = "Serverless Jobs"
+	productRdb        = "RDB"
+	productKapsule    = "Kapsule"
+	productInstance   = "Instance"
+	productLB         = "Load Balancer"
+	productS3         = "Object Storage"
+	productVPC        = "VPC"
+)
+
+func (d *ResourceDiscover) requests() []requestResources {
+	requests := []requestResources{
+		{
+			Product: productIAM,
+			Scope: discovery.Scope{
+				Types:    []resource.Type{resource.TypeIAMApplication},
+				Locality: resource.Global,
+			},
+			Get: d.discoverIAMApplications,
+		},
 	}
-	d.requested <- requestResources{
-		Get: d.discoverIAMApplications,
+
+	for _, project := range d.projects {
+		projectID := project.Metadata().ID
+		requests = append(requests, requestResources{
+			Product: productCockpit,
+			Scope: discovery.Scope{
+				Types:     []resource.Type{resource.TypeCockpit},
+				Locality:  resource.Global,
+				ProjectID: projectID,
+			},
+			Get: func(ctx context.Context) ([]resource.Resource, error) {
+				return d.discoverCockpitInProject(ctx, projectID)
+			},
+		})
 	}
+
 	for _, region := range d.regions {
 		region := region // !important
-		d.discoverInRegion(region, d.discoverRegistryNamespacesInRegion)
-		d.discoverInRegion(region, d.discoverContainersInRegion)
-		d.discoverInRegion(region, d.discoverFunctionsInRegion)
-		d.discoverInRegion(region, d.discoverRdbInstancesInRegion)
-		d.discoverInRegion(region, d.discoverKapsuleClustersInRegion)
-		d.discoverInRegion(region, d.discoverJobsInRegion)
+		requests = append(requests,
+			d.discoverInRegion(productRegistry, region, d.discoverRegistryNamespacesInRegion, resource.TypeRegistryNamespace),
+			d.discoverInRegion(productKapsule, region, d.discoverKapsuleClustersInRegion, resource.TypeKapsuleCluster, resource.TypeKapsulePool, resource.TypeKapsuleNode),
+			d.discoverInRegion(productJobs, region, d.discoverJobsInRegion, resource.TypeJobDefinition, resource.TypeJobRun),
+			d.discoverInRegion(productVPC, region, d.discoverPrivateNetworksInRegion, resource.TypeVPC, resource.TypePrivateNetwork, resource.TypePublicGateway),
+		)
+
+		for _, project := range d.projects {
+			projectID := project.Metadata().ID
+			requests = append(requests,
+				d.discoverInProjectRegion(productContainers, region, projectID, d.discoverContainersInRegion, resource.TypeContainerNamespace, resource.TypeContainer),
+				d.discoverInProjectRegion(productFunctions, region, projectID, d.discoverFunctionsInRegion, resource.TypeFunctionNamespace, resource.TypeFunction),
+				d.discoverInProjectRegion(productRdb, region, projectID, d.discoverRdbInstancesInRegion, resource.TypeRdbInstance),
+				d.discoverInProjectRegion(productS3, region, projectID, d.discoverBucketsInRegion, resource.TypeBucket),
+			)
+		}
 	}
 	for _, zone := range d.zones {
 		zone := zone // !important
-		d.discoverInZone(zone, d.discoverInstancesInZone)
+		requests = append(requests,
+			d.discoverInZone(productInstance, zone, d.discoverInstancesInZone, resource.TypeInstance),
+			d.discoverInZone(productInstance, zone, d.discoverVolumesInZone, resource.TypeVolume, resource.TypeSnapshot, resource.TypeFlexibleIP),
+		)
+
+		// Load Balancers are not available in every zone.
+		if slices.Contains(lb.NewZonedAPI(d.client).Zones(), zone) {
+			requests = append(requests,
+				d.discoverInZone(productLB, zone, d.discoverLoadBalancersInZone, resource.TypeLoadBalancer, resource.TypeLBFrontend, resource.TypeLBBackend),
+			)
+		}
 	}
 
-	return g.Wait()
+	return requests
 }
 
-func (d *ResourceDiscover) discoverInRegion(region scw.Region, discoverFunc func(ctx context.Context, region scw.Region) ([]resource.Resource, error)) {
-	d.requested <- requestResources{
+func (d *ResourceDiscover) discoverInRegion(product string, region scw.Region, discoverFunc func(ctx context.Context, region scw.Region) ([]resource.Resource, error), types ...resource.Type) requestResources {
+	return requestResources{
+		Product: product,
+		Scope: discovery.Scope{
+			Types:    types,
+			Locality: resource.Region(region),
+		},
 		Get: func(ctx context.Context) ([]resource.Resource, error) {
 			return discoverFunc(ctx, region)
 		},
 	}
 }
 
-func (d *ResourceDiscover) discoverInZone(zone scw.Zone, discoverFunc func(ctx context.Context, zone scw.Zone) ([]resource.Resource, error)) {
-	d.requested <- requestResources{
+func (d *ResourceDiscover) discoverInProjectRegion(product string, region scw.Region, projectID string, discoverFunc func(ctx context.Context, region scw.Region, projectID string) ([]resource.Resource, error), types ...resource.Type) requestResources {
+	return requestResources{
+		Product: product,
+		Scope: discovery.Scope{
+			Types:     types,
+			Locality:  resource.Region(region),
+			ProjectID: projectID,
+		},
+		Get: func(ctx context.Context) ([]resource.Resource, error) {
+			return discoverFunc(ctx, region, projectID)
+		},
+	}
+}
+
+func (d *ResourceDiscover) discoverInZone(product string, zone scw.Zone, discoverFunc func(ctx context.Context, zone scw.Zone) ([]resource.Resource, error), types ...resource.Type) requestResources {
+	return requestResources{
+		Product: product,
+		Scope: discovery.Scope{
+			Types:    types,
+			Locality: resource.Zone(zone),
+		},
 		Get: func(ctx context.Context) ([]resource.Resource, error) {
 			return discoverFunc(ctx, zone)
 		},
@@ -96,34 +208,136 @@ func (d *ResourceDiscover) discoverInZone(zone scw.Zone, discoverFunc func(ctx c
 }
 
 type requestResources struct {
+	Product      string
+	Scope        discovery.Scope
 	Get          func(ctx context.Context) ([]resource.Resource, error)
 	CurrentRetry int
 }
 
-func (d *ResourceDiscover) runWorker(ctx context.Context, ch chan resource.Resource) error {
+// discoveryPass holds the state shared by the workers during a single call to Discover.
+type discoveryPass struct {
+	// requested can hold all the requests, so that sending a request to retry never blocks,
+	// even once the workers are gone.
+	requested chan requestResources
+	ch        chan resource.Resource
+	reporter  discovery.Reporter
+
+	// done is closed once every request has been handled, so that the workers can stop.
+	done chan struct{}
+
+	mutex sync.Mutex
+	// pending is the number of requests that have not been handled yet.
+	pending   int
+	completed []discovery.Scope
+}
+
+func newDiscoveryPass(requested chan requestResources, ch chan resource.Resource, reporter discovery.Reporter, pending int) *discoveryPass {
+	p := &discoveryPass{
+		requested: requested,
+		ch:        ch,
+		reporter:  reporter,
+		done:      make(chan struct{}),
+		pending:   pending,
+	}
+	if pending == 0 {
+		close(p.done)
+	}
+	return p
+}
+
+// handled marks a request as handled, whether it succeeded or not.
+func (p *discoveryPass) handled() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.pending--
+	if p.pending == 0 {
+		close(p.done)
+	}
+}
+
+// retryAfter sends the request to the workers again once the delay has passed,
+// without holding a worker in the meantime.
+func (p *discoveryPass) retryAfter(req requestResources, delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		p.requested <- req
+	})
+}
+
+func (p *discoveryPass) complete(req requestResources, count int) {
+	p.mutex.Lock()
+	p.completed = append(p.completed, req.Scope)
+	p.mutex.Unlock()
+
+	p.reporter.Report(discovery.Event{
+		Product:   req.Product,
+		Locality:  req.Scope.Locality,
+		ProjectID: req.Scope.ProjectID,
+		Count:     count,
+		Time:      time.Now(),
+	})
+	p.handled()
+}
+
+func (p *discoveryPass) fail(req requestResources, err error) {
+	p.reporter.Report(discovery.Event{
+		Product:   req.Product,
+		Locality:  req.Scope.Locality,
+		ProjectID: req.Scope.ProjectID,
+		Err:       err,
+		Hint:      errorHint(err),
+		Time:      time.Now(),
+	})
+	p.handled()
+}
+
+func (d *ResourceDiscover) runWorker(ctx context.Context, pass *discoveryPass) error {
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case req := <-d.requested:
-			resources, err := req.Get(ctx)
+		case <-pass.done:
+			return nil
+		case req := <-pass.requested:
+			hint := &retryHint{}
+			resources, err := req.Get(withRetryHint(ctx, hint))
 			if err != nil {
-				if errors.Is(err, ErrShouldRetry) {
-					// Retry later
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				if errors.Is(err, ErrShouldRetry) && req.CurrentRetry < d.config.MaxRetries {
+					delay := d.config.backoff(req.CurrentRetry, hint)
 					req.CurrentRetry++
-					if req.CurrentRetry < d.config.MaxRetries {
-						d.requested <- req
-						continue
-					}
-					return err
+
+					d.logger.Debug("discover: retrying request",
+						slog.Int("retry", req.CurrentRetry),
+						slog.Duration("delay", delay),
+						slog.String("err", err.Error()))
+
+					pass.retryAfter(req, delay)
+					continue
 				}
-				d.logger.With("err", err).Error("discover: failed to get resources")
+
+				// A request that keeps failing only fails its own scope,
+				// the other resources can still be discovered.
+				d.logger.With("err", err).Error("discover: failed to get resources",
+					slog.String("product", req.Product),
+					slog.Int("retries", req.CurrentRetry))
+				pass.fail(req, err)
+				continue
 			}
 
 			d.logger.Info("discover: got resources", slog.Int("num_resources", len(resources)))
 			for _, r := range resources {
-				ch <- r
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case pass.ch <- r:
+				}
 			}
+
+			pass.complete(req, len(resources))
 		}
 	}
 }
@@ -145,14 +359,64 @@ func handleRequestError(err error) error {
 
 	var respErr *scw.ResponseError
 	if errors.As(err, &respErr) {
-		if respErr.StatusCode == http.StatusTooManyRequests {
-			return ErrShouldRetry
+		if respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%w: %w", ErrShouldRetry, err)
 		}
+		return err
+	}
+
+	// Errors that did not come with a response are transport errors,
+	// such as a connection reset or a timeout.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%w: %w", ErrShouldRetry, err)
 	}
 
 	return err
 }
 
+const (
+	hintMissingPermissions = "The API key is missing permissions. It needs the ProjectReadOnly and AllProductsReadOnly permission sets, or read access to this product."
+	hintRejectedKey        = "The API key was rejected. Check the credentials of the Scaleway profile."
+)
+
+// errorHint explains the most common reasons for a request to fail.
+func errorHint(err error) string {
+	var permErr *scw.PermissionsDeniedError
+	if errors.As(err, &permErr) {
+		return hintMissingPermissions
+	}
+
+	var authErr *scw.DeniedAuthenticationError
+	if errors.As(err, &authErr) {
+		return hintRejectedKey
+	}
+
+	if objectstorage.StatusCode(err) == http.StatusForbidden {
+		return hintMissingPermissions
+	}
+
+	var respErr *scw.ResponseError
+	if errors.As(err, &respErr) {
+		switch {
+		case respErr.StatusCode == http.StatusUnauthorized:
+			return hintRejectedKey
+		case respErr.StatusCode == http.StatusForbidden:
+			return hintMissingPermissions
+		case respErr.StatusCode == http.StatusTooManyRequests:
+			return "The API is rate limiting the requests. The discovery will try again later."
+		case respErr.StatusCode >= http.StatusInternalServerError:
+			return "The API is unavailable in this locality. The discovery will try again later."
+		}
+	}
+
+	if errors.Is(err, ErrShouldRetry) {
+		return "The API could not be reached. The discovery will try again later."
+	}
+
+	return ""
+}
+
 // discoveryRegions returns the list of regions to discover resources in.
 func discoveryRegions(logger *slog.Logger, client *scw.Client) []scw.Region {
 	region, ok := client.GetDefaultRegion()
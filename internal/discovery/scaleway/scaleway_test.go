@@ -0,0 +1,534 @@
+package scaleway
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/discovery"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	registryPath = "/registry/v1/regions/fr-par/namespaces"
+	kapsulePath  = "/k8s/v1/regions/fr-par/clusters"
+	instancePath = "/instance/v1/zones/fr-par-1/servers"
+	lbPath       = "/lb/v1/zones/fr-par-1/lbs"
+	volumePath   = "/instance/v1/zones/fr-par-1/volumes"
+	snapshotPath = "/instance/v1/zones/fr-par-1/snapshots"
+	ipPath       = "/instance/v1/zones/fr-par-1/ips"
+)
+
+// fakeAPI is a stand-in for the Scaleway API.
+// Each path fails with the configured responses before succeeding.
+type fakeAPI struct {
+	mutex    sync.Mutex
+	attempts map[string]int
+	failures map[string][]func(w http.ResponseWriter)
+	bodies   map[string]string
+}
+
+func (api *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mutex.Lock()
+	attempt := api.attempts[r.URL.Path]
+	api.attempts[r.URL.Path]++
+	failures := api.failures[r.URL.Path]
+	body, ok := api.bodies[r.URL.Path]
+	api.mutex.Unlock()
+
+	if attempt < len(failures) {
+		failures[attempt](w)
+		return
+	}
+
+	if !ok {
+		body = "{}"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(body))
+}
+
+func (api *fakeAPI) Attempts(path string) int {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	return api.attempts[path]
+}
+
+func respondWith(statusCode int, retryAfter string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(statusCode)
+	}
+}
+
+func closeConnection(w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		panic(err)
+	}
+	conn.Close()
+}
+
+func newTestDiscoverer(t *testing.T, api *fakeAPI) *ResourceDiscover {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+		scw.WithDefaultZone(scw.ZoneFrPar1),
+		scw.WithHTTPClient(NewHTTPClient()),
+	)
+	require.NoError(t, err)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewResourceDiscoverer(logger, client, nil, &ResourceDiscovererConfig{
+		NumWorkers: 4,
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	})
+}
+
+type recordingReporter struct {
+	mutex  sync.Mutex
+	events []discovery.Event
+}
+
+func (r *recordingReporter) Start(int) {}
+
+func (r *recordingReporter) Report(e discovery.Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, e)
+}
+
+func discover(t *testing.T, d *ResourceDiscover, reporter discovery.Reporter) ([]resource.Resource, []discovery.Scope) {
+	t.Helper()
+
+	ch := make(chan resource.Resource)
+	resources := make([]resource.Resource, 0)
+	done := make(chan struct{})
+
+	go func() {
+		for r := range ch {
+			resources = append(resources, r)
+		}
+		close(done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	scopes, err := d.Discover(ctx, ch, reporter)
+	close(ch)
+	<-done
+
+	require.NoError(t, err)
+	return resources, scopes
+}
+
+func hasScope(scopes []discovery.Scope, t resource.Type, locality resource.Locality) bool {
+	for _, s := range scopes {
+		for _, typ := range s.Types {
+			if typ == t && s.Locality.String() == locality.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestResourceDiscover_Discover(t *testing.T) {
+	api := &fakeAPI{
+		attempts: make(map[string]int),
+		failures: map[string][]func(w http.ResponseWriter){
+			registryPath: {
+				respondWith(http.StatusTooManyRequests, "0"),
+				respondWith(http.StatusTooManyRequests, ""),
+			},
+			kapsulePath: {
+				respondWith(http.StatusServiceUnavailable, ""),
+				respondWith(http.StatusServiceUnavailable, ""),
+				respondWith(http.StatusServiceUnavailable, ""),
+			},
+			instancePath: {
+				closeConnection,
+			},
+		},
+		bodies: map[string]string{
+			registryPath: `{"namespaces": [{"id": "namespace-id", "name": "namespace", "region": "fr-par"}], "total_count": 1}`,
+			instancePath: `{"servers": [{"id": "server-id", "name": "server", "zone": "fr-par-1"}]}`,
+		},
+	}
+
+	d := newTestDiscoverer(t, api)
+	reporter := &recordingReporter{}
+	resources, scopes := discover(t, d, reporter)
+
+	ids := make([]string, 0, len(resources))
+	for _, r := range resources {
+		ids = append(ids, r.Metadata().ID)
+	}
+
+	t.Run("rate limited requests are retried", func(t *testing.T) {
+		assert.Equal(t, 3, api.Attempts(registryPath))
+		assert.Contains(t, ids, "namespace-id")
+		assert.True(t, hasScope(scopes, resource.TypeRegistryNamespace, resource.Region(scw.RegionFrPar)))
+	})
+
+	t.Run("transport errors are retried", func(t *testing.T) {
+		assert.Equal(t, 2, api.Attempts(instancePath))
+		assert.Contains(t, ids, "server-id")
+		assert.True(t, hasScope(scopes, resource.TypeInstance, resource.Zone(scw.ZoneFrPar1)))
+	})
+
+	t.Run("exhausted retries only fail their own scope", func(t *testing.T) {
+		assert.Equal(t, 3, api.Attempts(kapsulePath))
+		assert.False(t, hasScope(scopes, resource.TypeKapsuleCluster, resource.Region(scw.RegionFrPar)))
+		assert.True(t, hasScope(scopes, resource.TypeKapsuleCluster, resource.Region(scw.RegionNlAms)))
+	})
+
+	t.Run("failures are reported", func(t *testing.T) {
+		var failures []discovery.Event
+		for _, e := range reporter.events {
+			if e.Err != nil {
+				failures = append(failures, e)
+			}
+		}
+
+		require.Len(t, failures, 1)
+		assert.Equal(t, productKapsule, failures[0].Product)
+		assert.Equal(t, "fr-par", failures[0].Locality.String())
+		assert.NotEmpty(t, failures[0].Hint)
+	})
+}
+
+func TestResourceDiscover_RetryDoesNotHoldWorker(t *testing.T) {
+	api := &fakeAPI{
+		attempts: make(map[string]int),
+		failures: map[string][]func(w http.ResponseWriter){
+			registryPath: {respondWith(http.StatusTooManyRequests, "1")},
+		},
+	}
+
+	d := newTestDiscoverer(t, api)
+	d.config.NumWorkers = 1
+	d.config.MaxBackoff = time.Second
+
+	start := time.Now()
+	reporter := &recordingReporter{}
+	_, scopes := discover(t, d, reporter)
+	require.True(t, hasScope(scopes, resource.TypeRegistryNamespace, resource.Region(scw.RegionFrPar)))
+
+	for _, e := range reporter.events {
+		if e.Product == productRegistry && e.Locality.String() == "fr-par" {
+			assert.GreaterOrEqual(t, e.Time.Sub(start), time.Second, "the Retry-After delay should be honored")
+			continue
+		}
+		assert.Less(t, e.Time.Sub(start), 500*time.Millisecond, "the other scopes should not wait for the throttled one")
+	}
+}
+
+func TestResourceDiscover_Cancelled(t *testing.T) {
+	api := &fakeAPI{
+		attempts: make(map[string]int),
+		bodies: map[string]string{
+			instancePath: `{"servers": [{"id": "server-id", "name": "server", "zone": "fr-par-1"}]}`,
+		},
+	}
+
+	d := newTestDiscoverer(t, api)
+	d.config.NumWorkers = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// nobody reads the resources, the pass stops once it is cancelled.
+	ch := make(chan resource.Resource)
+	done := make(chan error)
+	go func() {
+		_, err := d.Discover(ctx, ch, &recordingReporter{})
+		done <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the discovery should stop once cancelled")
+	}
+}
+
+func TestResourceDiscover_LoadBalancers(t *testing.T) {
+	api := &fakeAPI{
+		attempts: make(map[string]int),
+		bodies: map[string]string{
+			lbPath:                      `{"lbs": [{"id": "lb-id", "name": "lb", "project_id": "project-id", "zone": "fr-par-1"}], "total_count": 1}`,
+			lbPath + "/lb-id/frontends": `{"frontends": [{"id": "frontend-id", "name": "frontend", "inbound_port": 443}], "total_count": 1}`,
+			lbPath + "/lb-id/backends":  `{"backends": [{"id": "backend-id", "name": "backend", "pool": ["10.0.0.1"]}], "total_count": 1}`,
+		},
+	}
+
+	d := newTestDiscoverer(t, api)
+	resources, err := d.discoverLoadBalancersInZone(context.Background(), scw.ZoneFrPar1)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	lb := resources[0]
+	assert.Equal(t, resource.TypeLoadBalancer, lb.Metadata().Type)
+
+	for _, child := range resources[1:] {
+		nested, ok := child.(resource.Nested)
+		require.True(t, ok)
+		assert.Equal(t, lb.Metadata().ID, nested.Parent().Metadata().ID)
+		assert.Equal(t, "project-id", child.Metadata().ProjectID, "the children should belong to the project of the Load Balancer")
+	}
+	assert.Len(t, resource.Descendants(lb, resources), 2)
+}
+
+func TestResourceDiscover_Volumes(t *testing.T) {
+	api := &fakeAPI{
+		attempts: make(map[string]int),
+		bodies: map[string]string{
+			volumePath: `{"volumes": [
+				{"id": "volume-id", "name": "data", "project": "project-id", "zone": "fr-par-1", "state": "in_use", "server": {"id": "server-id", "name": "server"}},
+				{"id": "orphan-id", "name": "old", "project": "project-id", "zone": "fr-par-1", "state": "available"}
+			]}`,
+			snapshotPath: `{"snapshots": [{"id": "snapshot-id", "name": "data-backup", "project": "project-id", "zone": "fr-par-1", "state": "available", "base_volume": {"id": "volume-id", "name": "data"}}]}`,
+			ipPath:       `{"ips": [{"id": "ip-id", "address": "51.15.0.1", "project": "project-id", "zone": "fr-par-1", "state": "detached"}]}`,
+		},
+	}
+
+	d := newTestDiscoverer(t, api)
+	resources, err := d.discoverVolumesInZone(context.Background(), scw.ZoneFrPar1)
+	require.NoError(t, err)
+	require.Len(t, resources, 4)
+
+	types := make([]resource.Type, 0, len(resources))
+	for _, r := range resources {
+		types = append(types, r.Metadata().Type)
+	}
+	assert.Equal(t, []resource.Type{resource.TypeVolume, resource.TypeVolume, resource.TypeSnapshot, resource.TypeFlexibleIP}, types)
+
+	attached, ok := resources[0].(resource.Attachable)
+	require.True(t, ok)
+	require.NotNil(t, attached.AttachedTo())
+	assert.Equal(t, "server-id", attached.AttachedTo().Metadata().ID)
+	assert.Len(t, resources[0].(resource.Actionable).Actions(), 2)
+
+	orphan := resources[1].Metadata()
+	assert.Equal(t, resource.Status("available"), *orphan.Status, "the status of an orphaned volume should be kept")
+	assert.Contains(t, *orphan.Description, "orphaned")
+	assert.Nil(t, resources[1].(resource.Attachable).AttachedTo())
+	for _, action := range resources[1].(resource.Actionable).Actions() {
+		assert.NotEqual(t, "Detach", action.Name, "an orphaned volume cannot be detached")
+	}
+
+	ip := resources[3].Metadata()
+	assert.Equal(t, "51.15.0.1", ip.Name)
+	assert.Equal(t, resource.Status("detached"), *ip.Status)
+	assert.Equal(t, "orphaned", *ip.Description)
+	assert.Empty(t, resources[3].(resource.Actionable).Actions())
+}
+
+func TestResourceDiscover_PrivateNetworks(t *testing.T) {
+	api := &fakeAPI{
+		attempts: make(map[string]int),
+		bodies: map[string]string{
+			"/vpc/v2/regions/fr-par/vpcs":             `{"vpcs": [{"id": "vpc-id", "name": "vpc", "project_id": "project-id", "region": "fr-par"}], "total_count": 1}`,
+			"/vpc/v2/regions/fr-par/private-networks": `{"private_networks": [{"id": "pn-id", "name": "pn", "project_id": "project-id", "region": "fr-par", "vpc_id": "vpc-id", "subnets": [{"id": "subnet-id", "subnet": "172.16.0.0/22"}]}], "total_count": 1}`,
+			"/vpc-gw/v1/zones/fr-par-1/gateways":      `{"gateways": [{"id": "gw-id", "name": "gw", "zone": "fr-par-1", "gateway_networks": [{"id": "gwn-id", "private_network_id": "pn-id"}]}], "total_count": 1}`,
+			"/ipam/v1/regions/fr-par/ips": `{"ips": [
+				{"id": "ip-1", "address": "172.16.0.2/22", "source": {"private_network_id": "pn-id"}, "resource": {"type": "instance_private_nic", "id": "nic-id", "mac_address": "02:00:00:00:00:01"}},
+				{"id": "ip-2", "address": "172.16.0.3/22", "source": {"private_network_id": "pn-id"}, "resource": {"type": "lb_server", "id": "lb-server-id"}},
+				{"id": "ip-3", "address": "172.16.0.1/22", "source": {"private_network_id": "pn-id"}, "resource": {"type": "vpc_gateway_network", "id": "gwn-id"}}
+			], "total_count": 3}`,
+			instancePath:                       `{"servers": [{"id": "server-id", "name": "server", "zone": "fr-par-1", "private_nics": [{"id": "nic-id", "private_network_id": "pn-id", "mac_address": "02:00:00:00:00:01"}]}]}`,
+			"/rdb/v1/regions/fr-par/instances": `{"instances": [{"id": "db-id", "name": "db", "region": "fr-par", "endpoints": [{"id": "endpoint-id", "private_network": {"private_network_id": "pn-id", "service_ip": "172.16.0.4/22"}}]}], "total_count": 1}`,
+			kapsulePath:                        `{"clusters": [{"id": "cluster-id", "name": "cluster", "region": "fr-par", "private_network_id": "pn-id"}], "total_count": 1}`,
+			lbPath:                             `{"lbs": [{"id": "lb-id", "name": "lb", "zone": "fr-par-1"}], "total_count": 1}`,
+			lbPath + "/lb-id/private-networks": `{"private_network": [{"private_network_id": "pn-id", "ipam_ids": ["ip-2"]}], "total_count": 1}`,
+			"/lb/v1/zones/fr-par-2/lbs":        `{"lbs": [{"id": "other-lb-id", "name": "other", "zone": "fr-par-2"}], "total_count": 1}`,
+			"/lb/v1/zones/fr-par-2/lbs/other-lb-id/private-networks": `{"private_network": [{"private_network_id": "unknown-pn-id"}], "total_count": 1}`,
+		},
+	}
+
+	d := newTestDiscoverer(t, api)
+	resources, err := d.discoverPrivateNetworksInRegion(context.Background(), scw.RegionFrPar)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	network, ok := resources[1].(resource.Networked)
+	require.True(t, ok)
+	assert.Equal(t, "172.16.0.0/22", *network.Metadata().Description)
+	assert.Equal(t, "vpc-id", network.(resource.Nested).Parent().Metadata().ID)
+	assert.Equal(t, resource.TypePublicGateway, resources[2].Metadata().Type)
+
+	assert.Equal(t, []resource.NetworkAttachment{
+		{ID: "db-id", Name: "db", Type: resource.TypeRdbInstance, Locality: "fr-par", Address: "172.16.0.4"},
+		{ID: "cluster-id", Name: "cluster", Type: resource.TypeKapsuleCluster, Locality: "fr-par"},
+		{ID: "server-id", Name: "server", Type: resource.TypeInstance, Locality: "fr-par-1", Address: "172.16.0.2"},
+		{ID: "lb-id", Name: "lb", Type: resource.TypeLoadBalancer, Locality: "fr-par-1", Address: "172.16.0.3"},
+		{ID: "gw-id", Name: "gw", Type: resource.TypePublicGateway, Locality: "fr-par-1", Address: "172.16.0.1"},
+	}, network.Attachments(), "the attachments should be sorted by type")
+}
+
+func TestResourceDiscover_KapsulePools(t *testing.T) {
+	api := &fakeAPI{
+		attempts: make(map[string]int),
+		bodies: map[string]string{
+			kapsulePath:                       `{"clusters": [{"id": "cluster-id", "name": "cluster", "project_id": "project-id", "region": "fr-par", "version": "1.28.2"}], "total_count": 1}`,
+			kapsulePath + "/cluster-id/pools": `{"pools": [{"id": "pool-id", "name": "default", "cluster_id": "cluster-id", "node_type": "DEV1-M", "autoscaling": true, "size": 2, "min_size": 1, "max_size": 5, "version": "1.27.4", "zone": "fr-par-1", "region": "fr-par"}], "total_count": 1}`,
+			kapsulePath + "/cluster-id/nodes": `{"nodes": [
+				{"id": "node-1", "name": "node-1", "pool_id": "pool-id", "cluster_id": "cluster-id", "status": "ready", "region": "fr-par"},
+				{"id": "node-2", "name": "node-2", "pool_id": "pool-id", "cluster_id": "cluster-id", "status": "rebooting", "region": "fr-par"}
+			], "total_count": 2}`,
+		},
+	}
+
+	d := newTestDiscoverer(t, api)
+	resources, err := d.discoverKapsuleClustersInRegion(context.Background(), scw.RegionFrPar)
+	require.NoError(t, err)
+	require.Len(t, resources, 4)
+
+	cluster, pool := resources[0], resources[1]
+	assert.Equal(t, resource.TypeKapsulePool, pool.Metadata().Type)
+	assert.Equal(t, "DEV1-M, 2 nodes, autoscaling from 1 to 5, 1.27.4", *pool.Metadata().Description)
+	assert.Equal(t, "project-id", pool.Metadata().ProjectID, "the pool should belong to the project of the cluster")
+	assert.Len(t, resource.Descendants(cluster, resources), 3)
+
+	node := resources[3]
+	assert.Equal(t, pool.Metadata().ID, node.(resource.Nested).Parent().Metadata().ID)
+	assert.Equal(t, resource.Status("rebooting"), *node.Metadata().Status)
+	assert.Equal(t, "DEV1-M", *node.Metadata().Description)
+
+	actions := pool.(resource.Actionable).Actions()
+	require.Len(t, actions, 1, "an autoscaled pool should only be upgraded")
+	assert.Equal(t, "Upgrade to 1.28.2", actions[0].Name)
+}
+
+func TestResourceDiscover_Buckets(t *testing.T) {
+	s3 := &testhelpers.FakeS3{
+		Buckets:   map[string]map[string]testhelpers.FakeObject{"assets": {}, "backups": {}},
+		CreatedAt: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	server := httptest.NewServer(s3)
+	t.Cleanup(server.Close)
+
+	d := newTestDiscoverer(t, &fakeAPI{attempts: make(map[string]int)})
+	d.config.ObjectStorageEndpoint = server.URL
+
+	resources, err := d.discoverBucketsInRegion(context.Background(), scw.RegionNlAms, "project-id")
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+
+	metadata := resources[0].Metadata()
+	assert.Equal(t, resource.TypeBucket, metadata.Type)
+	assert.Equal(t, "nl-ams/assets", metadata.ID)
+	assert.Equal(t, "project-id", metadata.ProjectID)
+	assert.Equal(t, resource.Region(scw.RegionNlAms), metadata.Locality)
+	assert.Equal(t, []string{"SCWXXXXXXXXXXXXXXXXX@project-id"}, s3.AccessKeys, "the buckets should be listed in the project")
+}
+
+func TestResourceDiscover_BucketsDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+	}))
+	t.Cleanup(server.Close)
+
+	d := newTestDiscoverer(t, &fakeAPI{attempts: make(map[string]int)})
+	d.config.ObjectStorageEndpoint = server.URL
+
+	_, err := d.discoverBucketsInRegion(context.Background(), scw.RegionFrPar, "project-id")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrShouldRetry)
+	assert.Equal(t, hintMissingPermissions, errorHint(err))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "3", 3 * time.Second, true},
+		{"negative seconds", "-1", 0, false},
+		{"http date", now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{"http date in the past", now.Add(-10 * time.Second).Format(http.TimeFormat), 0, true},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(test.value, now)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestResourceDiscovererConfig_Backoff(t *testing.T) {
+	config := &ResourceDiscovererConfig{
+		MinBackoff: time.Second,
+		MaxBackoff: 10 * time.Second,
+	}
+
+	for retry := 0; retry < 64; retry++ {
+		expected := config.MaxBackoff
+		if retry < 4 {
+			expected = config.MinBackoff << retry
+		}
+
+		got := config.backoff(retry, nil)
+		assert.GreaterOrEqual(t, got, expected/2, "retry %d", retry)
+		assert.LessOrEqual(t, got, expected, "retry %d", retry)
+	}
+
+	assert.Equal(t, 7*time.Second, config.backoff(0, &retryHint{after: 7 * time.Second}))
+
+	t.Run("retry after is capped", func(t *testing.T) {
+		assert.Equal(t, config.MaxBackoff, config.backoff(0, &retryHint{after: 42 * time.Hour}))
+	})
+}
+
+func TestRetryAfterTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		respondWith(http.StatusTooManyRequests, "7")(w)
+	}))
+	defer server.Close()
+
+	hint := &retryHint{}
+	req, err := http.NewRequestWithContext(withRetryHint(context.Background(), hint), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := NewHTTPClient().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, 7*time.Second, hint.after)
+}
@@ -0,0 +1,64 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// discoverVolumesInZone discovers the volumes, snapshots and flexible IPs of the Instance API.
+func (d *ResourceDiscover) discoverVolumesInZone(ctx context.Context, zone scw.Zone) ([]resource.Resource, error) {
+	api := sdk.NewAPI(d.client)
+
+	volumes, err := api.ListVolumes(&sdk.ListVolumesRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := api.ListSnapshots(&sdk.ListSnapshotsRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	ips, err := api.ListIPs(&sdk.ListIPsRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	resources := make([]resource.Resource, 0, len(volumes.Volumes)+len(snapshots.Snapshots)+len(ips.IPs))
+
+	for _, volume := range volumes.Volumes {
+		if volume == nil {
+			continue
+		}
+
+		resources = append(resources, scaleway.Volume(*volume))
+	}
+
+	for _, snapshot := range snapshots.Snapshots {
+		if snapshot == nil {
+			continue
+		}
+
+		resources = append(resources, scaleway.Snapshot(*snapshot))
+	}
+
+	for _, ip := range ips.IPs {
+		if ip == nil {
+			continue
+		}
+
+		resources = append(resources, scaleway.FlexibleIP(*ip))
+	}
+
+	return resources, nil
+}
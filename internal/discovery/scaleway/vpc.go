@@ -0,0 +1,346 @@
+package scaleway
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	instance "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	lb "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	rdb "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// discoverPrivateNetworksInRegion discovers the VPCs, their Private Networks and the Public Gateways of the region.
+// The resources attached to each Private Network are recorded with it, to show its topology.
+func (d *ResourceDiscover) discoverPrivateNetworksInRegion(ctx context.Context, region scw.Region) ([]resource.Resource, error) {
+	api := sdk.NewAPI(d.client)
+
+	vpcs, err := api.ListVPCs(&sdk.ListVPCsRequest{
+		Region: region,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	privateNetworks, err := api.ListPrivateNetworks(&sdk.ListPrivateNetworksRequest{
+		Region: region,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	gateways, err := d.listGatewaysInRegion(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make(networkAttachments, len(privateNetworks.PrivateNetworks))
+	for _, pn := range privateNetworks.PrivateNetworks {
+		if pn != nil {
+			attachments[pn.ID] = nil
+		}
+	}
+	// the other products are only queried when there is something to attach to.
+	if len(attachments) > 0 {
+		if err := d.discoverNetworkAttachments(ctx, region, attachments, gateways); err != nil {
+			return nil, err
+		}
+	}
+
+	resources := make([]resource.Resource, 0, len(vpcs.Vpcs)+len(privateNetworks.PrivateNetworks)+len(gateways))
+	vpcsByID := make(map[string]sdk.VPC, len(vpcs.Vpcs))
+
+	for _, vpc := range vpcs.Vpcs {
+		if vpc == nil {
+			continue
+		}
+
+		vpcsByID[vpc.ID] = *vpc
+		resources = append(resources, scaleway.VPC(*vpc))
+	}
+
+	for _, pn := range privateNetworks.PrivateNetworks {
+		if pn == nil {
+			continue
+		}
+
+		resources = append(resources, scaleway.PrivateNetwork{
+			PrivateNetwork: *pn,
+			VPC:            vpcsByID[pn.VpcID],
+			Attached:       attachments[pn.ID],
+		})
+	}
+
+	for _, gw := range gateways {
+		resources = append(resources, scaleway.PublicGateway(*gw))
+	}
+
+	return resources, nil
+}
+
+func (d *ResourceDiscover) listGatewaysInRegion(ctx context.Context, region scw.Region) ([]*vpcgw.Gateway, error) {
+	api := vpcgw.NewAPI(d.client)
+
+	var gateways []*vpcgw.Gateway
+	for _, zone := range zonesOf(region, api.Zones()) {
+		r, err := api.ListGateways(&vpcgw.ListGatewaysRequest{
+			Zone: zone,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return nil, err
+		}
+
+		for _, gw := range r.Gateways {
+			if gw != nil {
+				gateways = append(gateways, gw)
+			}
+		}
+	}
+
+	return gateways, nil
+}
+
+// networkAttachments are the resources attached to each Private Network, by ID.
+type networkAttachments map[string][]resource.NetworkAttachment
+
+// add records the attachment, if the Private Network was discovered.
+func (a networkAttachments) add(privateNetworkID string, attachment resource.NetworkAttachment) {
+	if _, ok := a[privateNetworkID]; !ok {
+		return
+	}
+	a[privateNetworkID] = append(a[privateNetworkID], attachment)
+}
+
+// ipamAddresses are the addresses booked in the Private Networks, by network and by resource ID, MAC address or IP ID.
+type ipamAddresses map[string]string
+
+func ipamKey(privateNetworkID, key string) string {
+	return privateNetworkID + "/" + key
+}
+
+// lookup returns the address of the first key found in the Private Network.
+func (a ipamAddresses) lookup(privateNetworkID string, keys ...string) string {
+	for _, key := range keys {
+		if address, ok := a[ipamKey(privateNetworkID, key)]; ok {
+			return address
+		}
+	}
+	return ""
+}
+
+// nolint:funlen // one request per product
+func (d *ResourceDiscover) discoverNetworkAttachments(ctx context.Context, region scw.Region, attachments networkAttachments, gateways []*vpcgw.Gateway) error {
+	addresses, err := d.listIPAMAddresses(ctx, region)
+	if err != nil {
+		return err
+	}
+
+	privateNetworkIDs := make([]string, 0, len(attachments))
+	for id := range attachments {
+		privateNetworkIDs = append(privateNetworkIDs, id)
+	}
+	sort.Strings(privateNetworkIDs)
+
+	instanceAPI := instance.NewAPI(d.client)
+	for _, zone := range zonesOf(region, instanceAPI.Zones()) {
+		servers, err := instanceAPI.ListServers(&instance.ListServersRequest{
+			Zone:            zone,
+			PrivateNetworks: privateNetworkIDs,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return err
+		}
+
+		for _, server := range servers.Servers {
+			if server == nil {
+				continue
+			}
+			for _, nic := range server.PrivateNics {
+				if nic == nil {
+					continue
+				}
+				attachments.add(nic.PrivateNetworkID, resource.NetworkAttachment{
+					ID:       server.ID,
+					Name:     server.Name,
+					Type:     resource.TypeInstance,
+					Locality: zone.String(),
+					Address:  addresses.lookup(nic.PrivateNetworkID, nic.ID, nic.MacAddress),
+				})
+			}
+		}
+	}
+
+	rdbAPI := rdb.NewAPI(d.client)
+	if slices.Contains(rdbAPI.Regions(), region) {
+		instances, err := rdbAPI.ListInstances(&rdb.ListInstancesRequest{
+			Region: region,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return err
+		}
+
+		for _, db := range instances.Instances {
+			if db == nil {
+				continue
+			}
+			for _, endpoint := range db.Endpoints {
+				if endpoint == nil || endpoint.PrivateNetwork == nil {
+					continue
+				}
+				attachments.add(endpoint.PrivateNetwork.PrivateNetworkID, resource.NetworkAttachment{
+					ID:       db.ID,
+					Name:     db.Name,
+					Type:     resource.TypeRdbInstance,
+					Locality: region.String(),
+					Address:  endpoint.PrivateNetwork.ServiceIP.IP.String(),
+				})
+			}
+		}
+	}
+
+	k8sAPI := k8s.NewAPI(d.client)
+	if slices.Contains(k8sAPI.Regions(), region) {
+		clusters, err := k8sAPI.ListClusters(&k8s.ListClustersRequest{
+			Region: region,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return err
+		}
+
+		for _, cluster := range clusters.Clusters {
+			if cluster == nil || cluster.PrivateNetworkID == nil {
+				continue
+			}
+			// the addresses are booked by the nodes, the cluster itself has none.
+			attachments.add(*cluster.PrivateNetworkID, resource.NetworkAttachment{
+				ID:       cluster.ID,
+				Name:     cluster.Name,
+				Type:     resource.TypeKapsuleCluster,
+				Locality: region.String(),
+				Address:  addresses.lookup(*cluster.PrivateNetworkID, cluster.ID),
+			})
+		}
+	}
+
+	lbAPI := lb.NewZonedAPI(d.client)
+	for _, zone := range zonesOf(region, lbAPI.Zones()) {
+		lbs, err := lbAPI.ListLBs(&lb.ZonedAPIListLBsRequest{
+			Zone: zone,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err = handleRequestError(err); err != nil {
+			return err
+		}
+
+		for _, loadBalancer := range lbs.LBs {
+			if loadBalancer == nil {
+				continue
+			}
+
+			networks, err := lbAPI.ListLBPrivateNetworks(&lb.ZonedAPIListLBPrivateNetworksRequest{
+				Zone: zone,
+				LBID: loadBalancer.ID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err = handleRequestError(err); err != nil {
+				return err
+			}
+
+			for _, network := range networks.PrivateNetwork {
+				if network == nil {
+					continue
+				}
+				keys := append(slices.Clone(network.IpamIDs), loadBalancer.ID)
+				attachments.add(network.PrivateNetworkID, resource.NetworkAttachment{
+					ID:       loadBalancer.ID,
+					Name:     loadBalancer.Name,
+					Type:     resource.TypeLoadBalancer,
+					Locality: zone.String(),
+					Address:  addresses.lookup(network.PrivateNetworkID, keys...),
+				})
+			}
+		}
+	}
+
+	for _, gw := range gateways {
+		for _, network := range gw.GatewayNetworks {
+			if network == nil {
+				continue
+			}
+			address := addresses.lookup(network.PrivateNetworkID, network.ID)
+			if address == "" && network.Address != nil {
+				address = network.Address.IP.String()
+			}
+			attachments.add(network.PrivateNetworkID, resource.NetworkAttachment{
+				ID:       gw.ID,
+				Name:     gw.Name,
+				Type:     resource.TypePublicGateway,
+				Locality: gw.Zone.String(),
+				Address:  address,
+			})
+		}
+	}
+
+	// the attachments are stored with the Private Network, so their order must not change between discoveries.
+	for _, attached := range attachments {
+		sort.Slice(attached, func(i, j int) bool {
+			if attached[i].Type != attached[j].Type {
+				return attached[i].Type < attached[j].Type
+			}
+			return attached[i].Name < attached[j].Name
+		})
+	}
+
+	return nil
+}
+
+func (d *ResourceDiscover) listIPAMAddresses(ctx context.Context, region scw.Region) (ipamAddresses, error) {
+	api := ipam.NewAPI(d.client)
+	if !slices.Contains(api.Regions(), region) {
+		return ipamAddresses{}, nil
+	}
+
+	ips, err := api.ListIPs(&ipam.ListIPsRequest{
+		Region: region,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err = handleRequestError(err); err != nil {
+		return nil, err
+	}
+
+	addresses := make(ipamAddresses, len(ips.IPs))
+	for _, ip := range ips.IPs {
+		if ip == nil || ip.Source == nil || ip.Source.PrivateNetworkID == nil {
+			continue
+		}
+
+		pnID := *ip.Source.PrivateNetworkID
+		address := ip.Address.IP.String()
+
+		addresses[ipamKey(pnID, ip.ID)] = address
+		if ip.Resource == nil {
+			continue
+		}
+		addresses[ipamKey(pnID, ip.Resource.ID)] = address
+		if ip.Resource.MacAddress != nil {
+			addresses[ipamKey(pnID, *ip.Resource.MacAddress)] = address
+		}
+	}
+
+	return addresses, nil
+}
+
+// zonesOf returns the zones of the region where the product is available.
+func zonesOf(region scw.Region, available []scw.Zone) []scw.Zone {
+	zones := make([]scw.Zone, 0, len(available))
+	for _, zone := range region.GetZones() {
+		if slices.Contains(available, zone) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
@@ -0,0 +1,190 @@
+// Package objectstorage browses the buckets of Object Storage.
+// Object Storage is S3-compatible, so it is not part of the regional APIs of the SDK.
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// Delimiter separates the "directories" in the keys of the objects.
+const Delimiter = "/"
+
+// ErrMissingKeys is returned when the profile has no access key or secret key.
+var ErrMissingKeys = errors.New("objectstorage: the profile has no access key or secret key")
+
+// Endpoint returns the endpoint of Object Storage in a region.
+func Endpoint(region scw.Region) string {
+	return fmt.Sprintf("https://s3.%s.scw.cloud", region)
+}
+
+// Bucket is a bucket, as listed by Object Storage.
+type Bucket struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// Object is an object, or a prefix shared by several objects.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+	IsPrefix     bool
+}
+
+// Name returns the key of the object, relative to its prefix.
+func (o Object) Name(prefix string) string {
+	return strings.TrimPrefix(o.Key, prefix)
+}
+
+type Client struct {
+	s3 *minio.Client
+}
+
+// NewClient returns a client of Object Storage in a region, authenticated with the keys of the Scaleway client.
+// The endpoint includes the scheme, such as "https://s3.fr-par.scw.cloud".
+func NewClient(client *scw.Client, endpoint string, region scw.Region, projectID string) (*Client, error) {
+	accessKey, ok := client.GetAccessKey()
+	if !ok {
+		return nil, ErrMissingKeys
+	}
+	secretKey, ok := client.GetSecretKey()
+	if !ok {
+		return nil, ErrMissingKeys
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("objectstorage: invalid endpoint %q: %w", endpoint, err)
+	}
+
+	// Object Storage uses the preferred project of the key,
+	// unless another project is appended to the access key.
+	if projectID != "" {
+		accessKey += "@" + projectID
+	}
+
+	s3, err := minio.New(u.Host, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: u.Scheme == "https",
+		Region: string(region),
+		// the names of the buckets may contain dots, which do not match the certificate of the endpoint.
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{s3: s3}, nil
+}
+
+func (c *Client) ListBuckets(ctx context.Context) ([]Bucket, error) {
+	infos, err := c.s3.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make([]Bucket, 0, len(infos))
+	for _, info := range infos {
+		buckets = append(buckets, Bucket{Name: info.Name, CreatedAt: info.CreationDate})
+	}
+	return buckets, nil
+}
+
+// ListObjects returns the objects directly under the prefix, and the prefixes of the objects nested deeper.
+func (c *Client) ListObjects(ctx context.Context, bucket, prefix string) ([]Object, error) {
+	var objects []Object
+	for info := range c.s3.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if info.Err != nil {
+			return nil, info.Err
+		}
+		// some tools create an empty object to mark the "directory".
+		if info.Key == prefix {
+			continue
+		}
+		objects = append(objects, Object{
+			Key:          info.Key,
+			Size:         info.Size,
+			LastModified: info.LastModified,
+			IsPrefix:     strings.HasSuffix(info.Key, Delimiter),
+		})
+	}
+
+	// like in a file browser, the "directories" come first.
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].IsPrefix != objects[j].IsPrefix {
+			return objects[i].IsPrefix
+		}
+		return objects[i].Key < objects[j].Key
+	})
+	return objects, nil
+}
+
+// Download writes the object to a file, without overwriting an existing one.
+// It returns the number of bytes written.
+func (c *Client) Download(ctx context.Context, bucket, key, path string) (int64, error) {
+	obj, err := c.s3.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer obj.Close()
+
+	// the object is checked before the file is created, so that a missing object does not leave an empty file.
+	if _, err := obj.Stat(); err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(f, obj)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// do not leave a partial file behind.
+		_ = os.Remove(path)
+		return 0, err
+	}
+	return n, nil
+}
+
+func (c *Client) DeleteObject(ctx context.Context, bucket, key string) error {
+	return c.s3.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+}
+
+// DeleteBucket deletes the bucket, which must be empty.
+func (c *Client) DeleteBucket(ctx context.Context, bucket string) error {
+	return c.s3.RemoveBucket(ctx, bucket)
+}
+
+// PresignedURL returns a URL to download the object without credentials, until it expires.
+func (c *Client) PresignedURL(ctx context.Context, bucket, key string, expiry time.Duration) (string, error) {
+	u, err := c.s3.PresignedGetObject(ctx, bucket, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// StatusCode returns the HTTP status code of an error returned by Object Storage, or 0 if there was no response.
+func StatusCode(err error) int {
+	var respErr minio.ErrorResponse
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode
+	}
+	return 0
+}
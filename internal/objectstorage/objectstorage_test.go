@@ -0,0 +1,124 @@
+package objectstorage_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/objectstorage"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, s3 *testhelpers.FakeS3) *objectstorage.Client {
+	t.Helper()
+
+	server := httptest.NewServer(s3)
+	t.Cleanup(server.Close)
+
+	scwClient, err := scw.NewClient(scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"))
+	require.NoError(t, err)
+
+	client, err := objectstorage.NewClient(scwClient, server.URL, scw.RegionFrPar, "project-id")
+	require.NoError(t, err)
+	return client
+}
+
+func TestEndpoint(t *testing.T) {
+	assert.Equal(t, "https://s3.nl-ams.scw.cloud", objectstorage.Endpoint(scw.RegionNlAms))
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	modified := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	s3 := &testhelpers.FakeS3{Buckets: map[string]map[string]testhelpers.FakeObject{
+		"assets": {
+			"index.html":       {Body: "<html></html>", LastModified: modified},
+			"img/":             {},
+			"img/logo.png":     {Body: "png", LastModified: modified},
+			"img/old/logo.png": {Body: "old", LastModified: modified},
+		},
+	}}
+	client := newTestClient(t, s3)
+
+	t.Run("list buckets of the project", func(t *testing.T) {
+		buckets, err := client.ListBuckets(ctx)
+		require.NoError(t, err)
+		require.Len(t, buckets, 1)
+		assert.Equal(t, "assets", buckets[0].Name)
+		assert.Contains(t, s3.AccessKeys, "SCWXXXXXXXXXXXXXXXXX@project-id")
+	})
+
+	t.Run("list objects and prefixes", func(t *testing.T) {
+		objects, err := client.ListObjects(ctx, "assets", "")
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+		assert.Equal(t, objectstorage.Object{Key: "img/", IsPrefix: true}, objects[0])
+		assert.Equal(t, "index.html", objects[1].Key)
+		assert.Equal(t, int64(13), objects[1].Size)
+		assert.True(t, modified.Equal(objects[1].LastModified))
+
+		objects, err = client.ListObjects(ctx, "assets", "img/")
+		require.NoError(t, err)
+		require.Len(t, objects, 2, "the marker of the prefix should be skipped")
+		assert.Equal(t, "old/", objects[0].Name("img/"))
+		assert.Equal(t, "logo.png", objects[1].Name("img/"))
+	})
+
+	t.Run("download without overwriting", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "logo.png")
+		n, err := client.Download(ctx, "assets", "img/logo.png", path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "png", string(b))
+
+		_, err = client.Download(ctx, "assets", "img/logo.png", path)
+		assert.ErrorIs(t, err, os.ErrExist)
+	})
+
+	t.Run("missing object does not create a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing")
+		_, err := client.Download(ctx, "assets", "missing", path)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusNotFound, objectstorage.StatusCode(err))
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("presigned url", func(t *testing.T) {
+		raw, err := client.PresignedURL(ctx, "assets", "index.html", time.Hour)
+		require.NoError(t, err)
+
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		assert.Equal(t, "/assets/index.html", u.Path)
+		assert.Equal(t, "3600", u.Query().Get("X-Amz-Expires"))
+
+		resp, err := http.Get(raw) //nolint:noctx // test
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("delete bucket only once empty", func(t *testing.T) {
+		err := client.DeleteBucket(ctx, "assets")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusConflict, objectstorage.StatusCode(err))
+
+		for _, key := range s3.Objects("assets") {
+			require.NoError(t, client.DeleteObject(ctx, "assets", key))
+		}
+		require.NoError(t, client.DeleteBucket(ctx, "assets"))
+		assert.False(t, s3.HasBucket("assets"))
+	})
+}
@@ -0,0 +1,161 @@
+package cockpit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the service under which the secret keys are stored in the keyring of the OS.
+const keyringService = "scwtui-cockpit"
+
+// cachedToken is a token kept between runs.
+type cachedToken struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// SecretKey is stored in the keyring, never in the file.
+	SecretKey string `json:"-"`
+}
+
+// tokenCache keeps the secret keys of the tokens between runs, as they can only be read when the tokens are created.
+// The secret keys are stored in the keyring of the OS, by token ID,
+// the file only says which token belongs to which project.
+type tokenCache struct {
+	path  string
+	mutex sync.Mutex
+}
+
+func newTokenCache(path string) *tokenCache {
+	return &tokenCache{path: path}
+}
+
+// Get returns the token of a project, if it has the given name.
+func (c *tokenCache) Get(projectID, name string) (cachedToken, bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	tokens, err := c.read()
+	if err != nil {
+		return cachedToken{}, false, err
+	}
+
+	token, ok := tokens[projectID]
+	if !ok || token.Name != name {
+		return cachedToken{}, false, nil
+	}
+
+	secretKey, err := keyring.Get(keyringService, token.ID)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return cachedToken{}, false, nil
+	}
+	if err != nil {
+		return cachedToken{}, false, fmt.Errorf("cockpit: unable to read secret key from keyring: %w", err)
+	}
+	token.SecretKey = secretKey
+	return token, true, nil
+}
+
+// Put saves the token of a project.
+func (c *tokenCache) Put(projectID string, token cachedToken) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	tokens, err := c.read()
+	if err != nil {
+		return err
+	}
+
+	if err := keyring.Set(keyringService, token.ID, token.SecretKey); err != nil {
+		return fmt.Errorf("cockpit: unable to save secret key to keyring: %w", err)
+	}
+	if previous, ok := tokens[projectID]; ok && previous.ID != token.ID {
+		deleteSecretKey(previous.ID)
+	}
+
+	tokens[projectID] = token
+	return c.write(tokens)
+}
+
+// Delete removes the token of a project, if its ID matches.
+// An empty ID removes the token of the project regardless of its ID.
+func (c *tokenCache) Delete(projectID, tokenID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	tokens, err := c.read()
+	if err != nil {
+		return err
+	}
+
+	token, ok := tokens[projectID]
+	if !ok || (tokenID != "" && token.ID != tokenID) {
+		return nil
+	}
+	deleteSecretKey(token.ID)
+	delete(tokens, projectID)
+	return c.write(tokens)
+}
+
+// deleteSecretKey removes the secret key of a token from the keyring.
+// A key left behind is harmless once its token is deleted, so the errors are ignored.
+func deleteSecretKey(tokenID string) {
+	_ = keyring.Delete(keyringService, tokenID)
+}
+
+func (c *tokenCache) read() (map[string]cachedToken, error) {
+	b, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]cachedToken), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cockpit: unable to read token cache: %w", err)
+	}
+
+	tokens := make(map[string]cachedToken)
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, fmt.Errorf("cockpit: unable to decode token cache: %w", err)
+	}
+	return tokens, nil
+}
+
+func (c *tokenCache) write(tokens map[string]cachedToken) error {
+	b, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("cockpit: unable to encode token cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("cockpit: unable to create token cache directory: %w", err)
+	}
+
+	// the file is replaced at once, so that a crash does not leave a partial file.
+	f, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("cockpit: unable to write token cache: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	// CreateTemp already restricts the file to the current user, this makes it explicit.
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return fmt.Errorf("cockpit: unable to write token cache: %w", err)
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("cockpit: unable to write token cache: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("cockpit: unable to write token cache: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), c.path); err != nil {
+		return fmt.Errorf("cockpit: unable to write token cache: %w", err)
+	}
+	return nil
+}
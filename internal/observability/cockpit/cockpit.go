@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cyclimse/scwtui/internal/resource"
@@ -17,39 +21,63 @@ import (
 )
 
 const (
-	tokenName = "scwtui"
-
-	// queryTemplateWithName is the template used to query logs from Loki.
-	queryTemplateWithName = `{resource_name="%s", resource_type="%s"}`
-	// queryTemplateServerless is the template used to query logs from Loki for Serverless Functions & Containers.
-	// Because Serverless logs are sent as JSON, we only get the message field.
-	queryTemplateServerless = `{resource_name="%s", resource_type="%s"} |~ "^{.*}$" | json | line_format "{{.message}}"`
-	// queryTemplateWithID is the template used to query logs from Loki.
-	queryTemplateWithID = `{resource_id="%s", resource_type="%s"}`
+	// selectorTemplateWithName is the template of the labels used to select the logs and metrics of a resource.
+	selectorTemplateWithName = `resource_name="%s", resource_type="%s"`
+	// selectorTemplateWithID is the template of the labels used to select the logs and metrics of a resource.
+	selectorTemplateWithID = `resource_id="%s", resource_type="%s"`
+
+	// defaultLogsRange is the time range of the logs when the query does not specify a start.
+	defaultLogsRange = 24 * time.Hour
+	// defaultLogsLimit is the maximum number of logs when the query does not specify a limit.
+	defaultLogsLimit = 5000
 )
 
 // ErrCockpitNotActivated is returned when the cockpit is not activated for a project.
 var ErrCockpitNotActivated = fmt.Errorf("cockpit: cockpit not activated")
 
-func NewCockpit(logger *slog.Logger, scwClient *scw.Client) *Cockpit {
-	return &Cockpit{
-		logger:                logger,
-		scwClient:             scwClient,
-		httpClient:            &http.Client{},
-		tokenPerProject:       make(map[string]sdk.Token),
-		lokiAddressPerProject: make(map[string]string),
+type Config struct {
+	// TokenName is the name of the tokens created by scwtui, see DefaultTokenName.
+	TokenName string
+	// TokenCachePath is the file listing the tokens kept between runs, their secret keys are in the keyring.
+	// The tokens are created again on each run if empty.
+	TokenCachePath string
+}
+
+func NewCockpit(logger *slog.Logger, scwClient *scw.Client, cfg *Config) *Cockpit {
+	c := &Cockpit{
+		logger:              logger,
+		scwClient:           scwClient,
+		httpClient:          &http.Client{},
+		tokenName:           cfg.TokenName,
+		tokenPerProject:     make(map[string]sdk.Token),
+		tokenLocks:          make(map[string]*sync.Mutex),
+		endpointsPerProject: make(map[string]sdk.CockpitEndpoints),
+	}
+	if c.tokenName == "" {
+		c.tokenName = tokenNamePrefix
 	}
+	if cfg.TokenCachePath != "" {
+		c.cache = newTokenCache(cfg.TokenCachePath)
+	}
+	return c
 }
 
 type Cockpit struct {
-	logger                *slog.Logger
-	scwClient             *scw.Client
-	httpClient            *http.Client
-	tokenPerProject       map[string]sdk.Token
-	lokiAddressPerProject map[string]string
+	logger     *slog.Logger
+	scwClient  *scw.Client
+	httpClient *http.Client
+	tokenName  string
+	cache      *tokenCache
+
+	// mutex guards the maps, as the resources are queried at the same time.
+	mutex               sync.Mutex
+	tokenPerProject     map[string]sdk.Token
+	endpointsPerProject map[string]sdk.CockpitEndpoints
+	// tokenLocks are held while creating the token of a project, see tokenLock.
+	tokenLocks map[string]*sync.Mutex
 }
 
-func (c *Cockpit) Logs(ctx context.Context, r resource.Resource) ([]resource.Log, error) {
+func (c *Cockpit) Logs(ctx context.Context, r resource.Resource, q resource.LogsQuery) ([]resource.Log, error) {
 	metadata := r.Metadata()
 	cockpitMetadata := r.CockpitMetadata()
 
@@ -57,41 +85,108 @@ func (c *Cockpit) Logs(ctx context.Context, r resource.Resource) ([]resource.Log
 		return nil, nil
 	}
 
-	address, err := c.lokiAddressForProject(metadata.ProjectID)
+	endpoints, err := c.endpointsForProject(metadata.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 
-	token, err := c.tokenForProject(metadata.ProjectID)
-	if err != nil {
-		return nil, fmt.Errorf("cockpit: unable to get token for project %s: %w", metadata.ProjectID, err)
+	query := buildQuery(r)
+	if q.Filter != "" {
+		query += " " + q.Filter
 	}
+	start, end, limit := withDefaults(q, time.Now())
 
-	query := buildQuery(r)
-	start := time.Now().Add(-24 * time.Hour)
-	end := time.Now()
-	limit := 5000
+	queryURL := buildQueryURL(endpoints.LogsURL, query, start, end, limit, q.Direction)
 
-	queryURL := buildQueryURL(address, query, start, end, limit)
+	header := http.Header{}
+	header.Set("X-Datasource", "product")
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	resp, err := c.get(ctx, metadata.ProjectID, queryURL, header)
 	if err != nil {
-		return nil, fmt.Errorf("cockpit: unable to create request: %w", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseQueryError(resp)
+	}
+
+	return c.parseLogs(resp)
+}
+
+// get sends a query with the token of the project.
+// The token is created again if Cockpit rejects it, for instance when it was revoked or created again on another machine.
+func (c *Cockpit) get(ctx context.Context, projectID, queryURL string, header http.Header) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		token, err := c.tokenForProject(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("cockpit: unable to get token for project %s: %w", projectID, err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cockpit: unable to create request: %w", err)
+		}
+
+		for name := range header {
+			req.Header.Set(name, header.Get(name))
+		}
+		req.Header.Set("X-Token", *token.SecretKey)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("cockpit: unable to execute request: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			resp.Body.Close()
+			c.logger.Info("cockpit: token was rejected, creating a new one", slog.String("project_id", projectID))
+			c.invalidateToken(projectID, token)
+			continue
+		}
+		return resp, nil
 	}
+}
+
+// maxErrorBodySize is the maximum size of the error body read from Loki.
+const maxErrorBodySize = 4096
+
+// QueryError is returned when Loki rejects a query, for instance when the LogQL is invalid.
+type QueryError struct {
+	StatusCode int
+	// Message is the reason given by Loki, if any.
+	Message string
+}
 
-	req.Header.Set("X-Token", *token.SecretKey)
-	req.Header.Set("X-Datasource", "product")
+func (e *QueryError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("cockpit: unexpected status code: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("cockpit: query failed with status code %d: %s", e.StatusCode, e.Message)
+}
 
-	resp, err := c.httpClient.Do(req)
+// parseQueryError reads the reason of a failed query.
+// Loki usually answers with a plain text body, but some proxies answer with JSON.
+func parseQueryError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
 	if err != nil {
-		return nil, fmt.Errorf("cockpit: unable to execute request: %w", err)
+		return &QueryError{StatusCode: resp.StatusCode}
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("cockpit: unexpected status code: %d", resp.StatusCode)
+	var jsonBody struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if json.Unmarshal(body, &jsonBody) == nil {
+		if jsonBody.Message != "" {
+			return &QueryError{StatusCode: resp.StatusCode, Message: jsonBody.Message}
+		}
+		if jsonBody.Error != "" {
+			return &QueryError{StatusCode: resp.StatusCode, Message: jsonBody.Error}
+		}
 	}
 
-	return c.parseLogs(resp)
+	return &QueryError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
 }
 
 type QueryResponse struct {
@@ -108,14 +203,9 @@ type QueryResponseData struct {
 type Streams []Stream
 
 type Stream struct {
-	Stream  StreamMetadata `json:"stream"`
-	Entries []Entry        `json:"values"`
-}
-
-type StreamMetadata struct {
-	ResourceID   string `json:"resource_id"`
-	ResourceName string `json:"resource_name"`
-	ResourceType string `json:"resource_type"`
+	// Labels are the labels of the stream, such as resource_id and resource_type.
+	Labels  map[string]string `json:"stream"`
+	Entries []Entry           `json:"values"`
 }
 
 func (c *Cockpit) parseLogs(resp *http.Response) ([]resource.Log, error) {
@@ -132,13 +222,15 @@ func (c *Cockpit) parseLogs(resp *http.Response) ([]resource.Log, error) {
 
 		for _, stream := range streams {
 			for _, entry := range stream.Entries {
-				logs = append(logs, resource.Log{
-					Timestamp: entry.Timestamp,
-					Line:      entry.Line,
-				})
+				logs = append(logs, resource.NewLog(entry.Timestamp, entry.Line, stream.Labels))
 			}
 		}
 
+		// the entries are sorted by stream, in the direction of the query.
+		sort.SliceStable(logs, func(i, j int) bool {
+			return logs[i].Timestamp.Before(logs[j].Timestamp)
+		})
+
 		return logs, nil
 	default:
 		return nil, fmt.Errorf("cockpit: unexpected result type: %s", r.Data.ResultType)
@@ -146,110 +238,86 @@ func (c *Cockpit) parseLogs(resp *http.Response) ([]resource.Log, error) {
 }
 
 func buildQuery(r resource.Resource) string {
+	// Serverless logs are sent as JSON, their fields are parsed by resource.NewLog.
+	return "{" + selector(r) + "}"
+}
+
+// selector returns the labels that select the logs and metrics of a resource.
+func selector(r resource.Resource) string {
 	metadata := r.Metadata()
 	cockpitMetadata := r.CockpitMetadata()
 
 	if metadata.Type == resource.TypeFunction || metadata.Type == resource.TypeContainer {
-		return fmt.Sprintf(queryTemplateServerless, cockpitMetadata.ResourceName, cockpitMetadata.ResourceType)
+		return fmt.Sprintf(selectorTemplateWithName, cockpitMetadata.ResourceName, cockpitMetadata.ResourceType)
 	}
 
 	if cockpitMetadata.ResourceID != "" {
-		return fmt.Sprintf(queryTemplateWithID, cockpitMetadata.ResourceID, cockpitMetadata.ResourceType)
+		return fmt.Sprintf(selectorTemplateWithID, cockpitMetadata.ResourceID, cockpitMetadata.ResourceType)
 	}
-	return fmt.Sprintf(queryTemplateWithName, cockpitMetadata.ResourceName, cockpitMetadata.ResourceType)
-}
-
-func buildQueryURL(address, query string, start, end time.Time, limit int) string {
-	queryURL := address + "/loki/api/v1/query_range?query=" + url.QueryEscape(query)
-	queryURL += "&limit=" + strconv.Itoa(limit)
-	queryURL += "&start=" + url.QueryEscape(start.UTC().Format(time.RFC3339))
-	queryURL += "&end=" + url.QueryEscape(end.UTC().Format(time.RFC3339))
-	return queryURL
+	return fmt.Sprintf(selectorTemplateWithName, cockpitMetadata.ResourceName, cockpitMetadata.ResourceType)
 }
 
-func (c *Cockpit) lokiAddressForProject(projectID string) (string, error) {
-	if address, ok := c.lokiAddressPerProject[projectID]; ok {
-		return address, nil
+// withDefaults returns the bounds of the query, using the defaults for the missing values.
+func withDefaults(q resource.LogsQuery, now time.Time) (time.Time, time.Time, int) {
+	end := q.End
+	if end.IsZero() {
+		end = now
 	}
 
-	api := sdk.NewAPI(c.scwClient)
+	start := q.Start
+	if start.IsZero() {
+		start = end.Add(-defaultLogsRange)
+	}
 
-	cockpit, err := api.GetCockpit(&sdk.GetCockpitRequest{
-		ProjectID: projectID,
-	})
-	if err != nil {
-		var resourceNotFoundError *scw.ResourceNotFoundError
-		if errors.As(err, &resourceNotFoundError) {
-			return "", ErrCockpitNotActivated
-		}
-		return "", fmt.Errorf("cockpit: unable to get cockpit for project %s: %w", projectID, err)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultLogsLimit
 	}
 
-	c.lokiAddressPerProject[projectID] = cockpit.Endpoints.LogsURL
-	return cockpit.Endpoints.LogsURL, nil
+	return start, end, limit
 }
 
-func (c *Cockpit) tokenForProject(projectID string) (*sdk.Token, error) {
-	if token, ok := c.tokenPerProject[projectID]; ok {
-		return &token, nil
+func buildQueryURL(address, query string, start, end time.Time, limit int, direction resource.LogsDirection) string {
+	queryURL := address + "/loki/api/v1/query_range?query=" + url.QueryEscape(query)
+	queryURL += "&limit=" + strconv.Itoa(limit)
+	// the nanoseconds matter to only get the new logs when following them.
+	queryURL += "&start=" + url.QueryEscape(start.UTC().Format(time.RFC3339Nano))
+	queryURL += "&end=" + url.QueryEscape(end.UTC().Format(time.RFC3339Nano))
+	if direction == resource.LogsForward {
+		queryURL += "&direction=forward"
+	} else {
+		queryURL += "&direction=backward"
 	}
+	return queryURL
+}
 
-	// we attempt to remove the previously generated token with the same name
-	// this avoids having a lot of dangling tokens
-	err := c.deleteTokenWithExistingName(projectID)
-	if err != nil {
-		c.logger.Warn("cockpit: unable to delete token with existing name",
-			slog.String("project_id", projectID),
-			slog.String("error", err.Error()))
+func (c *Cockpit) endpointsForProject(projectID string) (sdk.CockpitEndpoints, error) {
+	c.mutex.Lock()
+	endpoints, ok := c.endpointsPerProject[projectID]
+	c.mutex.Unlock()
+	if ok {
+		return endpoints, nil
 	}
 
 	api := sdk.NewAPI(c.scwClient)
 
-	// we have to recreate a token as the secret key is not returned by the API
-	// it's only available when creating the token
-	token, err := api.CreateToken(&sdk.CreateTokenRequest{
+	cockpit, err := api.GetCockpit(&sdk.GetCockpitRequest{
 		ProjectID: projectID,
-		Name:      tokenName,
-		Scopes: &sdk.TokenScopes{
-			QueryLogs: true,
-		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("cockpit: unable to create token for project %s: %w", projectID, err)
-	}
-
-	if token == nil || token.SecretKey == nil {
-		return nil, fmt.Errorf("cockpit: unable to get secret key for token")
-	}
-
-	c.tokenPerProject[projectID] = *token
-	return token, nil
-}
-
-func (c *Cockpit) deleteTokenWithExistingName(projectID string) error {
-	api := sdk.NewAPI(c.scwClient)
-
-	req, err := api.ListTokens(&sdk.ListTokensRequest{
-		ProjectID: projectID,
-	}, scw.WithAllPages())
-	if err != nil {
-		return fmt.Errorf("cockpit: unable to list tokens for project %s: %w", projectID, err)
-	}
-
-	for _, token := range req.Tokens {
-		if token == nil {
-			continue
+		var resourceNotFoundError *scw.ResourceNotFoundError
+		if errors.As(err, &resourceNotFoundError) {
+			return sdk.CockpitEndpoints{}, ErrCockpitNotActivated
 		}
+		return sdk.CockpitEndpoints{}, fmt.Errorf("cockpit: unable to get cockpit for project %s: %w", projectID, err)
+	}
 
-		if token.Name == tokenName {
-			err := api.DeleteToken(&sdk.DeleteTokenRequest{
-				TokenID: token.ID,
-			})
-			if err != nil {
-				return fmt.Errorf("cockpit: unable to delete token for project %s: %w", projectID, err)
-			}
-		}
+	if cockpit.Endpoints == nil {
+		return sdk.CockpitEndpoints{}, fmt.Errorf("cockpit: no endpoints for project %s", projectID)
 	}
 
-	return nil
+	c.mutex.Lock()
+	c.endpointsPerProject[projectID] = *cockpit.Endpoints
+	c.mutex.Unlock()
+	return *cockpit.Endpoints, nil
 }
@@ -0,0 +1,180 @@
+package cockpit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testProjectID = "project-id"
+	testSecretKey = "secret-key"
+)
+
+// fakeLoki is a stand-in for the query_range endpoint of Loki.
+// It returns the lines after the start of the range that contain the text of a `|= "..."` line filter,
+// and rejects the queries that contain a parse error.
+type fakeLoki struct {
+	mutex      sync.Mutex
+	queries    []string
+	directions []string
+	lines      []resource.Log
+}
+
+func (l *fakeLoki) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/loki/api/v1/query_range" || r.Header.Get("X-Token") != testSecretKey {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query().Get("query")
+	l.mutex.Lock()
+	l.queries = append(l.queries, query)
+	l.directions = append(l.directions, r.URL.Query().Get("direction"))
+	l.mutex.Unlock()
+
+	start, err := time.Parse(time.RFC3339Nano, r.URL.Query().Get("start"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if strings.Contains(query, "|=|") {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, "parse error at line 1, col 42: syntax error: unexpected |\n")
+		return
+	}
+
+	var text string
+	if _, filter, ok := strings.Cut(query, `|= "`); ok {
+		text = strings.TrimSuffix(filter, `"`)
+	}
+
+	values := make([]string, 0, len(l.lines))
+	for _, line := range l.lines {
+		if !line.Timestamp.Before(start) && strings.Contains(line.Line, text) {
+			values = append(values, fmt.Sprintf(`["%d", %q]`, line.Timestamp.UnixNano(), line.Line))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprintf(w, `{"status": "success", "data": {"resultType": "streams", "result": [{"stream": {"resource_type": "rdb_instance"}, "values": [%s]}]}}`, strings.Join(values, ","))
+}
+
+func newTestCockpit(t *testing.T, handler http.Handler) *Cockpit {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := NewCockpit(slog.New(slog.NewTextHandler(io.Discard, nil)), nil, &Config{})
+	c.endpointsPerProject[testProjectID] = sdk.CockpitEndpoints{LogsURL: server.URL, MetricsURL: server.URL}
+	secretKey := testSecretKey
+	c.tokenPerProject[testProjectID] = sdk.Token{SecretKey: &secretKey}
+	return c
+}
+
+func testResource() resource.Resource {
+	return &testhelpers.MockResource{
+		MetadataValue: resource.Metadata{
+			ID:        "resource-id",
+			ProjectID: testProjectID,
+			Type:      resource.TypeRdbInstance,
+		},
+		CockpitMetadataValue: resource.CockpitMetadata{
+			CanViewLogs:  true,
+			ResourceID:   "resource-id",
+			ResourceType: "rdb_instance",
+		},
+	}
+}
+
+func TestCockpit_Logs(t *testing.T) {
+	now := time.Now()
+	loki := &fakeLoki{
+		lines: []resource.Log{
+			{Timestamp: now.Add(-2 * time.Minute), Line: "connection accepted"},
+			{Timestamp: now.Add(-time.Minute), Line: "error: connection refused"},
+			{Timestamp: now.Add(-time.Second), Line: `{"message": "checkpoint complete", "level": "info"}`},
+		},
+	}
+	c := newTestCockpit(t, loki)
+
+	t.Run("all logs without a filter", func(t *testing.T) {
+		logs, err := c.Logs(context.Background(), testResource(), resource.LogsQuery{})
+		require.NoError(t, err)
+		assert.Len(t, logs, 3)
+		assert.Equal(t, `{resource_id="resource-id", resource_type="rdb_instance"}`, loki.queries[len(loki.queries)-1])
+	})
+
+	t.Run("filter is appended to the selector", func(t *testing.T) {
+		logs, err := c.Logs(context.Background(), testResource(), resource.LogsQuery{Filter: `|= "error"`})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Equal(t, "error: connection refused", logs[0].Line)
+		assert.Equal(t, `{resource_id="resource-id", resource_type="rdb_instance"} |= "error"`, loki.queries[len(loki.queries)-1])
+	})
+
+	t.Run("logs are queried from the start of the range", func(t *testing.T) {
+		logs, err := c.Logs(context.Background(), testResource(), resource.LogsQuery{
+			Start:     loki.lines[1].Timestamp,
+			Direction: resource.LogsForward,
+		})
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+		assert.Equal(t, loki.lines[1].Timestamp.UnixNano(), logs[0].Timestamp.UnixNano())
+		assert.Equal(t, "forward", loki.directions[len(loki.directions)-1])
+	})
+
+	t.Run("labels and json fields are parsed", func(t *testing.T) {
+		logs, err := c.Logs(context.Background(), testResource(), resource.LogsQuery{Filter: `|= "checkpoint"`})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Equal(t, map[string]string{"resource_type": "rdb_instance"}, logs[0].Labels)
+		assert.Equal(t, "checkpoint complete", logs[0].Message())
+		assert.Equal(t, resource.LogLevelInfo, logs[0].Level())
+	})
+
+	t.Run("invalid queries are reported with the reason", func(t *testing.T) {
+		_, err := c.Logs(context.Background(), testResource(), resource.LogsQuery{Filter: `|=|`})
+
+		var queryErr *QueryError
+		require.ErrorAs(t, err, &queryErr)
+		assert.Equal(t, http.StatusBadRequest, queryErr.StatusCode)
+		assert.Equal(t, "parse error at line 1, col 42: syntax error: unexpected |", queryErr.Message)
+	})
+}
+
+func TestParseQueryError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"plain text", "parse error\n", "cockpit: query failed with status code 400: parse error"},
+		{"json message", `{"message": "invalid token"}`, "cockpit: query failed with status code 400: invalid token"},
+		{"json error", `{"error": "too many outstanding requests"}`, "cockpit: query failed with status code 400: too many outstanding requests"},
+		{"empty", "", "cockpit: unexpected status code: 400"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(test.body))}
+			assert.EqualError(t, parseQueryError(resp), test.want)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package cockpit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+const (
+	// defaultMetricsRange is the time range of the metrics when the query does not specify a start.
+	defaultMetricsRange = time.Hour
+	// minMetricsStep is the minimum duration between two samples, which is the scrape interval of Cockpit.
+	minMetricsStep = 15 * time.Second
+	// maxMetricsSamples is the maximum number of samples of a series, to pick a step when the query does not specify one.
+	maxMetricsSamples = 240
+	// minRateInterval is the minimum range of the rates, so that it covers several scrapes.
+	minRateInterval = time.Minute
+)
+
+// metricQuery is the PromQL query of a metric.
+// The selector of the resource replaces %[1]s, and the range of the rates replaces %[2]s.
+type metricQuery struct {
+	metric resource.Metric
+	unit   string
+	query  string
+}
+
+func serverlessMetricQueries(prefix string) []metricQuery {
+	return []metricQuery{
+		{resource.MetricCPU, "cores", `sum(rate(` + prefix + `_cpu_usage_seconds_total{%[1]s}[%[2]s]))`},
+		{resource.MetricMemory, "bytes", `sum(` + prefix + `_memory_usage_bytes{%[1]s})`},
+		{resource.MetricRequestRate, "req/s", `sum(rate(` + prefix + `_requests_total{%[1]s}[%[2]s]))`},
+		{resource.MetricErrorRate, "%", `100 * sum(rate(` + prefix + `_requests_total{%[1]s, status_code=~"5.."}[%[2]s])) / sum(rate(` + prefix + `_requests_total{%[1]s}[%[2]s]))`},
+	}
+}
+
+// metricQueries are the queries of the metrics of each Cockpit resource type.
+//
+// nolint:gochecknoglobals
+var metricQueries = map[string][]metricQuery{
+	"serverless_function":  serverlessMetricQueries("serverless_function"),
+	"serverless_container": serverlessMetricQueries("serverless_container"),
+	"rdb_instance_postgresql": {
+		{resource.MetricCPU, "%", `avg(rdb_instance_cpu_usage_percent{%[1]s})`},
+		{resource.MetricMemory, "%", `avg(rdb_instance_memory_usage_percent{%[1]s})`},
+		// the transactions are the closest thing to requests for a database.
+		{resource.MetricRequestRate, "tx/s", `sum(rate(rdb_instance_postgresql_xact_commit_total{%[1]s}[%[2]s]))`},
+		{resource.MetricErrorRate, "tx/s", `sum(rate(rdb_instance_postgresql_xact_rollback_total{%[1]s}[%[2]s]))`},
+	},
+	"kubernetes_cluster": {
+		{resource.MetricCPU, "cores", `sum(rate(kubernetes_cluster_node_cpu_seconds_total{%[1]s, mode!="idle"}[%[2]s]))`},
+		{resource.MetricMemory, "bytes", `sum(kubernetes_cluster_node_memory_used_bytes{%[1]s})`},
+		// the requests of a cluster are the ones of its control plane.
+		{resource.MetricRequestRate, "req/s", `sum(rate(kubernetes_cluster_apiserver_request_total{%[1]s}[%[2]s]))`},
+		{resource.MetricErrorRate, "%", `100 * sum(rate(kubernetes_cluster_apiserver_request_total{%[1]s, code=~"5.."}[%[2]s])) / sum(rate(kubernetes_cluster_apiserver_request_total{%[1]s}[%[2]s]))`},
+	},
+}
+
+func (c *Cockpit) Metrics(ctx context.Context, r resource.Resource, q resource.MetricsQuery) ([]resource.Series, error) {
+	metadata := r.Metadata()
+	cockpitMetadata := r.CockpitMetadata()
+
+	queries, ok := metricQueries[cockpitMetadata.ResourceType]
+	if !cockpitMetadata.CanViewMetrics || !ok {
+		return nil, nil
+	}
+
+	endpoints, err := c.endpointsForProject(metadata.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, step := withMetricsDefaults(q, time.Now())
+	rateInterval := formatPromDuration(max(4*step, minRateInterval))
+	sel := selector(r)
+
+	series := make([]resource.Series, len(queries))
+	errs := make([]error, len(queries))
+
+	// the metrics are independent, so they are queried at the same time.
+	var wg sync.WaitGroup
+	for i, mq := range queries {
+		i, mq := i, mq
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			queryURL := buildMetricsQueryURL(endpoints.MetricsURL, fmt.Sprintf(mq.query, sel, rateInterval), start, end, step)
+			samples, err := c.querySamples(ctx, metadata.ProjectID, queryURL)
+			series[i] = resource.Series{Metric: mq.metric, Unit: mq.unit, Samples: samples}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return series, nil
+}
+
+// withMetricsDefaults returns the bounds and the step of the query, using the defaults for the missing values.
+func withMetricsDefaults(q resource.MetricsQuery, now time.Time) (time.Time, time.Time, time.Duration) {
+	end := q.End
+	if end.IsZero() {
+		end = now
+	}
+
+	start := q.Start
+	if start.IsZero() {
+		start = end.Add(-defaultMetricsRange)
+	}
+
+	step := q.Step
+	if step <= 0 {
+		step = end.Sub(start) / maxMetricsSamples
+	}
+	step = max(step, minMetricsStep)
+
+	return start, end, step
+}
+
+// formatPromDuration formats a duration in seconds, which is always understood by Prometheus.
+func formatPromDuration(d time.Duration) string {
+	return strconv.Itoa(int(d.Seconds())) + "s"
+}
+
+func buildMetricsQueryURL(address, query string, start, end time.Time, step time.Duration) string {
+	queryURL := address + "/prometheus/api/v1/query_range?query=" + url.QueryEscape(query)
+	queryURL += "&start=" + strconv.FormatInt(start.Unix(), 10)
+	queryURL += "&end=" + strconv.FormatInt(end.Unix(), 10)
+	queryURL += "&step=" + formatPromDuration(step)
+	return queryURL
+}
+
+func (c *Cockpit) querySamples(ctx context.Context, projectID, queryURL string) ([]resource.Sample, error) {
+	resp, err := c.get(ctx, projectID, queryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseQueryError(resp)
+	}
+
+	return parseSamples(resp)
+}
+
+type MetricsResponse struct {
+	Status string              `json:"status"`
+	Data   MetricsResponseData `json:"data"`
+}
+
+type MetricsResponseData struct {
+	ResultType string `json:"resultType"`
+	// We only support matrix result type, which is the one of range queries.
+	Result []MetricsResult `json:"result"`
+}
+
+type MetricsResult struct {
+	Metric map[string]string `json:"metric"`
+	// Values are pairs of a Unix timestamp in seconds and a value as a string.
+	Values [][2]json.RawMessage `json:"values"`
+}
+
+// parseSamples reads the samples of a range query.
+// The queries aggregate the series of a resource, so only the first series is kept.
+func parseSamples(resp *http.Response) ([]resource.Sample, error) {
+	var r MetricsResponse
+	err := json.NewDecoder(resp.Body).Decode(&r)
+	if err != nil {
+		return nil, fmt.Errorf("cockpit: unable to decode response: %w", err)
+	}
+
+	if r.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("cockpit: unexpected result type: %s", r.Data.ResultType)
+	}
+	if len(r.Data.Result) == 0 {
+		return nil, nil
+	}
+
+	values := r.Data.Result[0].Values
+	samples := make([]resource.Sample, 0, len(values))
+	for _, v := range values {
+		var (
+			ts    float64
+			value string
+		)
+		if err := json.Unmarshal(v[0], &ts); err != nil {
+			return nil, fmt.Errorf("cockpit: invalid timestamp: %w", err)
+		}
+		if err := json.Unmarshal(v[1], &value); err != nil {
+			return nil, fmt.Errorf("cockpit: invalid value: %w", err)
+		}
+
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cockpit: invalid value: %w", err)
+		}
+		// for instance, the error rate is NaN when there are no requests.
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+
+		sec := int64(ts)
+		samples = append(samples, resource.Sample{
+			Timestamp: time.Unix(sec, int64((ts-float64(sec))*float64(time.Second))),
+			Value:     f,
+		})
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return samples, nil
+}
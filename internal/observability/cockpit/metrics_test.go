@@ -0,0 +1,111 @@
+package cockpit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePrometheus is a stand-in for the query_range endpoint of Prometheus.
+// It returns two samples for each query, the value being the number of the query.
+type fakePrometheus struct {
+	mutex   sync.Mutex
+	queries []string
+	steps   []string
+}
+
+func (p *fakePrometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/prometheus/api/v1/query_range" || r.Header.Get("X-Token") != testSecretKey {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query().Get("query")
+	p.mutex.Lock()
+	p.queries = append(p.queries, query)
+	p.steps = append(p.steps, r.URL.Query().Get("step"))
+	p.mutex.Unlock()
+
+	if strings.Contains(query, "requests_total") {
+		// the error rate is NaN without any request.
+		_, _ = fmt.Fprint(w, `{"status": "success", "data": {"resultType": "matrix", "result": [{"metric": {}, "values": [[1700000000, "NaN"], [1700000015.5, "2"]]}]}}`)
+		return
+	}
+	if strings.Contains(query, "memory") {
+		_, _ = fmt.Fprint(w, `{"status": "success", "data": {"resultType": "matrix", "result": []}}`)
+		return
+	}
+	_, _ = fmt.Fprint(w, `{"status": "success", "data": {"resultType": "matrix", "result": [{"metric": {}, "values": [[1700000015, "0.5"], [1700000000, "0.25"]]}]}}`)
+}
+
+func TestCockpit_Metrics(t *testing.T) {
+	prometheus := &fakePrometheus{}
+	c := newTestCockpit(t, prometheus)
+
+	function := &testhelpers.MockResource{
+		MetadataValue: resource.Metadata{ProjectID: testProjectID, Type: resource.TypeFunction},
+		CockpitMetadataValue: resource.CockpitMetadata{
+			CanViewMetrics: true,
+			ResourceName:   "function",
+			ResourceType:   "serverless_function",
+		},
+	}
+
+	end := time.Unix(1700003600, 0)
+	series, err := c.Metrics(context.Background(), function, resource.MetricsQuery{Start: end.Add(-time.Hour), End: end})
+	require.NoError(t, err)
+	require.Len(t, series, 4)
+
+	t.Run("the series are in the order of the metrics", func(t *testing.T) {
+		var metrics []resource.Metric
+		for _, s := range series {
+			metrics = append(metrics, s.Metric)
+		}
+		assert.Equal(t, []resource.Metric{resource.MetricCPU, resource.MetricMemory, resource.MetricRequestRate, resource.MetricErrorRate}, metrics)
+		assert.Equal(t, "bytes", series[1].Unit)
+	})
+
+	t.Run("the samples are sorted and parsed", func(t *testing.T) {
+		assert.Equal(t, []resource.Sample{
+			{Timestamp: time.Unix(1700000000, 0), Value: 0.25},
+			{Timestamp: time.Unix(1700000015, 0), Value: 0.5},
+		}, series[0].Samples)
+		assert.Empty(t, series[1].Samples)
+		assert.Equal(t, []resource.Sample{
+			{Timestamp: time.Unix(1700000015, int64(500*time.Millisecond)), Value: 2},
+		}, series[2].Samples, "the NaN values should be dropped")
+	})
+
+	t.Run("the queries select the resource", func(t *testing.T) {
+		assert.Contains(t, prometheus.queries, `sum(rate(serverless_function_cpu_usage_seconds_total{resource_name="function", resource_type="serverless_function"}[60s]))`)
+		// an hour is split in maxMetricsSamples samples.
+		assert.Equal(t, "15s", prometheus.steps[0])
+	})
+
+	t.Run("resources without metrics are skipped", func(t *testing.T) {
+		series, err := c.Metrics(context.Background(), testResource(), resource.MetricsQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, series)
+	})
+}
+
+func TestWithMetricsDefaults(t *testing.T) {
+	now := time.Now()
+
+	start, end, step := withMetricsDefaults(resource.MetricsQuery{}, now)
+	assert.Equal(t, now.Add(-defaultMetricsRange), start)
+	assert.Equal(t, now, end)
+	assert.Equal(t, minMetricsStep, step)
+
+	_, _, step = withMetricsDefaults(resource.MetricsQuery{Start: now.Add(-24 * time.Hour)}, now)
+	assert.Equal(t, 6*time.Minute, step)
+}
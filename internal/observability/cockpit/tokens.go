@@ -0,0 +1,249 @@
+package cockpit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	sdk "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// tokenNamePrefix is the prefix of the names of the tokens created by scwtui.
+// Older versions named all of their tokens after it.
+const tokenNamePrefix = "scwtui"
+
+// DefaultTokenName returns the name of the tokens of a machine, such as "scwtui-laptop".
+// Each machine has its own tokens, so that a session does not invalidate the ones of the other machines.
+func DefaultTokenName(hostname string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(hostname) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '.':
+			// only keep the short name of the host.
+			return joinTokenName(b.String())
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return joinTokenName(b.String())
+}
+
+func joinTokenName(suffix string) string {
+	suffix = strings.Trim(suffix, "-")
+	if suffix == "" {
+		return tokenNamePrefix
+	}
+	return tokenNamePrefix + "-" + suffix
+}
+
+// cachedTokenForProject returns the token of a project, if it was already created or loaded.
+func (c *Cockpit) cachedTokenForProject(projectID string) (sdk.Token, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	token, ok := c.tokenPerProject[projectID]
+	return token, ok
+}
+
+func (c *Cockpit) tokenForProject(ctx context.Context, projectID string) (sdk.Token, error) {
+	if token, ok := c.cachedTokenForProject(projectID); ok {
+		return token, nil
+	}
+
+	// the logs of several resources of a project are queried at the same time,
+	// they must not create a token each.
+	lock := c.tokenLock(projectID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if token, ok := c.cachedTokenForProject(projectID); ok {
+		return token, nil
+	}
+
+	if token, ok := c.loadToken(projectID); ok {
+		c.setToken(projectID, token)
+		return token, nil
+	}
+
+	token, err := c.createToken(ctx, projectID)
+	if err != nil {
+		return sdk.Token{}, err
+	}
+
+	c.setToken(projectID, token)
+	if c.cache != nil {
+		err := c.cache.Put(projectID, cachedToken{ID: token.ID, Name: token.Name, SecretKey: *token.SecretKey})
+		if err != nil {
+			c.logger.Warn("cockpit: unable to cache token", slog.String("project_id", projectID), slog.String("error", err.Error()))
+		}
+	}
+	return token, nil
+}
+
+// loadToken returns the token of a project saved by a previous run, if any.
+func (c *Cockpit) loadToken(projectID string) (sdk.Token, bool) {
+	if c.cache == nil {
+		return sdk.Token{}, false
+	}
+
+	cached, ok, err := c.cache.Get(projectID, c.tokenName)
+	if err != nil {
+		c.logger.Warn("cockpit: unable to load cached token", slog.String("project_id", projectID), slog.String("error", err.Error()))
+		return sdk.Token{}, false
+	}
+	if !ok {
+		return sdk.Token{}, false
+	}
+
+	secretKey := cached.SecretKey
+	return sdk.Token{ID: cached.ID, ProjectID: projectID, Name: cached.Name, SecretKey: &secretKey}, true
+}
+
+// tokenLock returns the lock held while creating the token of a project.
+// The tokens of different projects are created at the same time.
+func (c *Cockpit) tokenLock(projectID string) *sync.Mutex {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	lock, ok := c.tokenLocks[projectID]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.tokenLocks[projectID] = lock
+	}
+	return lock
+}
+
+func (c *Cockpit) setToken(projectID string, token sdk.Token) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.tokenPerProject[projectID] = token
+}
+
+// invalidateToken forgets the token of a project, once Cockpit rejected it.
+// The token is only forgotten if it was not already replaced by another query.
+func (c *Cockpit) invalidateToken(projectID string, token sdk.Token) {
+	c.mutex.Lock()
+	if current, ok := c.tokenPerProject[projectID]; ok && current.ID == token.ID {
+		delete(c.tokenPerProject, projectID)
+	}
+	c.mutex.Unlock()
+
+	if c.cache != nil {
+		if err := c.cache.Delete(projectID, token.ID); err != nil {
+			c.logger.Warn("cockpit: unable to remove cached token", slog.String("project_id", projectID), slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (c *Cockpit) createToken(ctx context.Context, projectID string) (sdk.Token, error) {
+	// we attempt to remove the previously generated token with the same name
+	// this avoids having a lot of dangling tokens
+	err := c.deleteTokenWithExistingName(ctx, projectID)
+	if err != nil {
+		c.logger.Warn("cockpit: unable to delete token with existing name",
+			slog.String("project_id", projectID),
+			slog.String("error", err.Error()))
+	}
+
+	api := sdk.NewAPI(c.scwClient)
+
+	// we have to recreate a token as the secret key is not returned by the API
+	// it's only available when creating the token
+	token, err := api.CreateToken(&sdk.CreateTokenRequest{
+		ProjectID: projectID,
+		Name:      c.tokenName,
+		Scopes: &sdk.TokenScopes{
+			QueryLogs:    true,
+			QueryMetrics: true,
+		},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return sdk.Token{}, fmt.Errorf("cockpit: unable to create token for project %s: %w", projectID, err)
+	}
+
+	if token == nil || token.SecretKey == nil {
+		return sdk.Token{}, fmt.Errorf("cockpit: unable to get secret key for token")
+	}
+
+	return *token, nil
+}
+
+func (c *Cockpit) deleteTokenWithExistingName(ctx context.Context, projectID string) error {
+	tokens, err := c.listTokens(ctx, projectID, c.tokenName)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if err := c.deleteToken(ctx, token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListTokens returns the tokens with the given name in the projects.
+// An empty name lists the tokens of this machine, only the exact name is matched,
+// so that the tokens created by hand or by other tools are never listed.
+func (c *Cockpit) ListTokens(ctx context.Context, projectIDs []string, name string) ([]sdk.Token, error) {
+	if name == "" {
+		name = c.tokenName
+	}
+
+	var tokens []sdk.Token
+	for _, projectID := range projectIDs {
+		projectTokens, err := c.listTokens(ctx, projectID, name)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, projectTokens...)
+	}
+	return tokens, nil
+}
+
+func (c *Cockpit) listTokens(ctx context.Context, projectID, name string) ([]sdk.Token, error) {
+	api := sdk.NewAPI(c.scwClient)
+
+	resp, err := api.ListTokens(&sdk.ListTokensRequest{
+		ProjectID: projectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("cockpit: unable to list tokens for project %s: %w", projectID, err)
+	}
+
+	var tokens []sdk.Token
+	for _, token := range resp.Tokens {
+		if token != nil && token.Name == name {
+			tokens = append(tokens, *token)
+		}
+	}
+	return tokens, nil
+}
+
+// RevokeToken deletes a token created by scwtui, and forgets its secret key.
+func (c *Cockpit) RevokeToken(ctx context.Context, token sdk.Token) error {
+	if err := c.deleteToken(ctx, token); err != nil {
+		return err
+	}
+	c.invalidateToken(token.ProjectID, token)
+	return nil
+}
+
+func (c *Cockpit) deleteToken(ctx context.Context, token sdk.Token) error {
+	api := sdk.NewAPI(c.scwClient)
+
+	err := api.DeleteToken(&sdk.DeleteTokenRequest{
+		TokenID: token.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("cockpit: unable to delete token %s of project %s: %w", token.Name, token.ProjectID, err)
+	}
+	return nil
+}
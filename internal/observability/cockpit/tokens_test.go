@@ -0,0 +1,232 @@
+package cockpit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+// fakeTokenAPI is a stand-in for the tokens endpoints of the Cockpit API.
+type fakeTokenAPI struct {
+	mutex   sync.Mutex
+	tokens  []sdk.Token
+	created int
+	deleted []string
+}
+
+func (f *fakeTokenAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/cockpit/v1beta1/tokens":
+		var tokens []sdk.Token
+		for _, token := range f.tokens {
+			if token.ProjectID == r.URL.Query().Get("project_id") {
+				token.SecretKey = nil
+				tokens = append(tokens, token)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(sdk.ListTokensResponse{Tokens: pointers(tokens), TotalCount: uint32(len(tokens))})
+	case r.Method == http.MethodPost && r.URL.Path == "/cockpit/v1beta1/tokens":
+		var req sdk.CreateTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.created++
+		secretKey := fmt.Sprintf("secret-%d", f.created)
+		token := sdk.Token{ID: fmt.Sprintf("token-%d", f.created), ProjectID: req.ProjectID, Name: req.Name, SecretKey: &secretKey}
+		f.tokens = append(f.tokens, token)
+		_ = json.NewEncoder(w).Encode(token)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/cockpit/v1beta1/tokens/"):
+		id := strings.TrimPrefix(r.URL.Path, "/cockpit/v1beta1/tokens/")
+		for i, token := range f.tokens {
+			if token.ID == id {
+				f.tokens = append(f.tokens[:i], f.tokens[i+1:]...)
+				break
+			}
+		}
+		f.deleted = append(f.deleted, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// valid returns true if the secret key belongs to an existing token.
+func (f *fakeTokenAPI) valid(secretKey string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, token := range f.tokens {
+		if token.SecretKey != nil && *token.SecretKey == secretKey {
+			return true
+		}
+	}
+	return false
+}
+
+func pointers(tokens []sdk.Token) []*sdk.Token {
+	p := make([]*sdk.Token, 0, len(tokens))
+	for i := range tokens {
+		p = append(p, &tokens[i])
+	}
+	return p
+}
+
+// newTokenTestCockpit returns a Cockpit whose Loki only accepts the tokens of the fake API.
+func newTokenTestCockpit(t *testing.T, api *fakeTokenAPI, cfg *Config) *Cockpit {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	loki := &fakeLoki{lines: []resource.Log{{Timestamp: time.Now().Add(-time.Minute), Line: "hello"}}}
+	lokiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !api.valid(r.Header.Get("X-Token")) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// the fake Loki expects the test secret key.
+		r.Header.Set("X-Token", testSecretKey)
+		loki.ServeHTTP(w, r)
+	}))
+	t.Cleanup(lokiServer.Close)
+
+	client, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+	)
+	require.NoError(t, err)
+
+	c := NewCockpit(slog.New(slog.NewTextHandler(io.Discard, nil)), client, cfg)
+	c.endpointsPerProject[testProjectID] = sdk.CockpitEndpoints{LogsURL: lokiServer.URL, MetricsURL: lokiServer.URL}
+	return c
+}
+
+func TestDefaultTokenName(t *testing.T) {
+	assert.Equal(t, "scwtui-laptop", DefaultTokenName("laptop"))
+	assert.Equal(t, "scwtui-my-laptop", DefaultTokenName("My_Laptop.local"))
+	assert.Equal(t, "scwtui", DefaultTokenName(""))
+}
+
+func TestCockpit_TokenLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("tokens of other machines are kept", func(t *testing.T) {
+		otherKey := "other-secret"
+		api := &fakeTokenAPI{tokens: []sdk.Token{
+			{ID: "old", ProjectID: testProjectID, Name: "scwtui-laptop"},
+			{ID: "other", ProjectID: testProjectID, Name: "scwtui-desktop", SecretKey: &otherKey},
+		}}
+		c := newTokenTestCockpit(t, api, &Config{TokenName: "scwtui-laptop"})
+
+		logs, err := c.Logs(ctx, testResource(), resource.LogsQuery{})
+		require.NoError(t, err)
+		assert.Len(t, logs, 1)
+
+		assert.Equal(t, []string{"old"}, api.deleted)
+		assert.True(t, api.valid(otherKey))
+	})
+
+	t.Run("token is created again when rejected", func(t *testing.T) {
+		api := &fakeTokenAPI{}
+		c := newTokenTestCockpit(t, api, &Config{TokenName: "scwtui-laptop"})
+
+		revoked := "revoked"
+		c.tokenPerProject[testProjectID] = sdk.Token{ID: "revoked", ProjectID: testProjectID, SecretKey: &revoked}
+
+		logs, err := c.Logs(ctx, testResource(), resource.LogsQuery{})
+		require.NoError(t, err)
+		assert.Len(t, logs, 1)
+		assert.Equal(t, 1, api.created)
+	})
+
+	t.Run("concurrent queries share the token", func(t *testing.T) {
+		api := &fakeTokenAPI{}
+		c := newTokenTestCockpit(t, api, &Config{TokenName: "scwtui-laptop"})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := c.Logs(ctx, testResource(), resource.LogsQuery{})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, api.created)
+	})
+
+	t.Run("cached token is reused on the next run", func(t *testing.T) {
+		keyring.MockInit()
+
+		api := &fakeTokenAPI{}
+		cachePath := filepath.Join(t.TempDir(), "tokens.json")
+		cfg := &Config{TokenName: "scwtui-laptop", TokenCachePath: cachePath}
+
+		_, err := newTokenTestCockpit(t, api, cfg).Logs(ctx, testResource(), resource.LogsQuery{})
+		require.NoError(t, err)
+
+		info, err := os.Stat(cachePath)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		b, err := os.ReadFile(cachePath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "secret", "the secret key should only be in the keyring")
+
+		_, err = newTokenTestCockpit(t, api, cfg).Logs(ctx, testResource(), resource.LogsQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, api.created)
+
+		// the token is created again if its secret key was removed from the keyring.
+		require.NoError(t, keyring.Delete(keyringService, "token-1"))
+		_, err = newTokenTestCockpit(t, api, cfg).Logs(ctx, testResource(), resource.LogsQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, api.created)
+	})
+
+	t.Run("only the tokens with the exact name are listed and revoked", func(t *testing.T) {
+		api := &fakeTokenAPI{tokens: []sdk.Token{
+			{ID: "legacy", ProjectID: testProjectID, Name: "scwtui"},
+			{ID: "laptop", ProjectID: testProjectID, Name: "scwtui-laptop"},
+			{ID: "desktop", ProjectID: testProjectID, Name: "scwtui-desktop"},
+			{ID: "by-hand", ProjectID: testProjectID, Name: "scwtui-grafana"},
+			{ID: "grafana", ProjectID: testProjectID, Name: "grafana"},
+		}}
+		c := newTokenTestCockpit(t, api, &Config{TokenName: "scwtui-laptop"})
+
+		tokens, err := c.ListTokens(ctx, []string{testProjectID}, "")
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		require.NoError(t, c.RevokeToken(ctx, tokens[0]))
+		assert.Equal(t, []string{"laptop"}, api.deleted)
+
+		tokens, err = c.ListTokens(ctx, []string{testProjectID}, "scwtui-desktop")
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, "desktop", tokens[0].ID)
+	})
+}
@@ -2,6 +2,8 @@ package demo
 
 import (
 	"context"
+	"encoding/json"
+	"math"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
@@ -17,21 +19,88 @@ func NewDemo() *Demo {
 	return &Demo{}
 }
 
-func (d *Demo) Logs(_ context.Context, _ resource.Resource) ([]resource.Log, error) {
+func (d *Demo) Logs(_ context.Context, _ resource.Resource, query resource.LogsQuery) ([]resource.Log, error) {
+	end := query.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := query.Start
+	if start.IsZero() {
+		start = end.Add(-time.Hour * 24 * 7)
+	}
+
 	numLogs := gofakeit.Number(1, maxNumLogs)
+	if query.Limit > 0 && numLogs > query.Limit {
+		numLogs = query.Limit
+	}
 
 	logs := make([]resource.Log, 0, numLogs)
-	begin := gofakeit.DateRange(time.Now().Add(-time.Hour*24*7), time.Now())
+	begin := gofakeit.DateRange(start, end)
 
-	for i := 0; i < numLogs; i++ {
-		logs = append(logs, resource.Log{
-			Timestamp: begin,
-			Line:      gofakeit.Sentence(gofakeit.Number(1, 10)),
-		})
+	for i := 0; i < numLogs && begin.Before(end); i++ {
+		logs = append(logs, resource.NewLog(begin, demoLine(), map[string]string{"resource_type": "demo"}))
 		begin = begin.Add(time.Duration(gofakeit.Number(1, 10)) * time.Minute)
 	}
 
 	return logs, nil
 }
 
+// Metrics returns random walks for all the metrics.
+func (d *Demo) Metrics(_ context.Context, r resource.Resource, query resource.MetricsQuery) ([]resource.Series, error) {
+	if !r.CockpitMetadata().CanViewMetrics {
+		return nil, nil
+	}
+
+	end := query.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := query.Start
+	if start.IsZero() {
+		start = end.Add(-time.Hour)
+	}
+	step := query.Step
+	if step <= 0 {
+		step = time.Minute
+	}
+
+	metrics := []struct {
+		metric resource.Metric
+		unit   string
+		max    float64
+	}{
+		{resource.MetricCPU, "cores", 2},
+		{resource.MetricMemory, "bytes", 512 * 1024 * 1024},
+		{resource.MetricRequestRate, "req/s", 50},
+		{resource.MetricErrorRate, "%", 10},
+	}
+
+	series := make([]resource.Series, 0, len(metrics))
+	for _, m := range metrics {
+		s := resource.Series{Metric: m.metric, Unit: m.unit}
+		value := gofakeit.Float64Range(0, m.max)
+		for t := start; !t.After(end); t = t.Add(step) {
+			value = math.Max(0, math.Min(m.max, value+gofakeit.Float64Range(-m.max/10, m.max/10)))
+			s.Samples = append(s.Samples, resource.Sample{Timestamp: t, Value: value})
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// demoLine returns either a plain line, or a structured line like the ones of Serverless.
+func demoLine() string {
+	message := gofakeit.Sentence(gofakeit.Number(1, 10))
+	if gofakeit.Bool() {
+		return message
+	}
+
+	line, _ := json.Marshal(map[string]string{
+		"message":    message,
+		"level":      gofakeit.RandomString([]string{"debug", "info", "info", "warn", "error"}),
+		"request_id": gofakeit.UUID(),
+	})
+	return string(line)
+}
+
 type Demo struct{}
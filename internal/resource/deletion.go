@@ -0,0 +1,232 @@
+package resource
+
+import (
+	"slices"
+	"sort"
+)
+
+// DeletionStep is the deletion of a resource, as part of a DeletionPlan.
+type DeletionStep struct {
+	Resource Resource
+
+	// DependsOn are the indexes of the steps that must succeed before this one.
+	// They always come before the step in the plan.
+	DependsOn []int
+}
+
+// DeletionPlan is the list of steps to delete several resources, in order.
+type DeletionPlan []DeletionStep
+
+// deletionStage is used to order the steps of a plan.
+type deletionStage int
+
+const (
+	// most resources can be deleted in any order, as long as the nested resources
+	// are deleted before their parent.
+	stageResources deletionStage = iota
+	// the cockpit is deleted after the resources, so their logs remain available until the end.
+	stageCockpit
+	// the project can only be deleted once it is empty.
+	stageProject
+)
+
+func stageOf(r Resource) deletionStage {
+	switch r.Metadata().Type {
+	case TypeCockpit:
+		return stageCockpit
+	case TypeProject:
+		return stageProject
+	default:
+		return stageResources
+	}
+}
+
+type planKey struct {
+	ID   string
+	Type Type
+}
+
+func planKeyOf(r Resource) planKey {
+	metadata := r.Metadata()
+	return planKey{ID: metadata.ID, Type: metadata.Type}
+}
+
+// PlanProjectDeletion returns the plan to delete a project and all of its resources.
+// Nested resources are deleted before their parent, e.g. the functions before their namespace,
+// attached resources after the resource they are attached to, e.g. the volumes after their instance,
+// and the resources attached to a Private Network before it, e.g. the instances and the gateways.
+// Then the cockpit is deleted, and the project last.
+func PlanProjectDeletion(resources []Resource) DeletionPlan {
+	byKey := make(map[planKey]Resource, len(resources))
+	for _, r := range resources {
+		byKey[planKeyOf(r)] = r
+	}
+
+	// parentOf returns the parent of the resource, if it is part of the plan.
+	parentOf := func(r Resource) (planKey, bool) {
+		nested, ok := r.(Nested)
+		if !ok || nested.Parent() == nil {
+			return planKey{}, false
+		}
+		k := planKeyOf(nested.Parent())
+		_, ok = byKey[k]
+		return k, ok
+	}
+
+	// attachedTo returns the resource the resource is attached to, if it is part of the plan.
+	attachedTo := func(r Resource) (planKey, bool) {
+		attachable, ok := r.(Attachable)
+		if !ok || attachable.AttachedTo() == nil {
+			return planKey{}, false
+		}
+		k := planKeyOf(attachable.AttachedTo())
+		_, ok = byKey[k]
+		return k, ok
+	}
+
+	// attachmentsOf returns the resources attached to the network, if they are part of the plan.
+	attachmentsOf := func(r Resource) []planKey {
+		networked, ok := r.(Networked)
+		if !ok {
+			return nil
+		}
+		var keys []planKey
+		for _, attachment := range networked.Attachments() {
+			k := planKey{ID: attachment.ID, Type: attachment.Type}
+			if _, ok := byKey[k]; ok {
+				keys = append(keys, k)
+			}
+		}
+		return keys
+	}
+
+	// depthOf returns the number of ancestors of the resource that are part of the plan.
+	depthOf := func(r Resource) int {
+		depth := 0
+		seen := map[planKey]struct{}{planKeyOf(r): {}}
+		for {
+			k, ok := parentOf(r)
+			if _, cycle := seen[k]; !ok || cycle {
+				return depth
+			}
+			seen[k] = struct{}{}
+			r = byKey[k]
+			depth++
+		}
+	}
+
+	depths := make(map[planKey]int, len(resources))
+	for k, r := range byKey {
+		depths[k] = depthOf(r)
+	}
+
+	sorted := make([]Resource, len(resources))
+	copy(sorted, resources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if stageOf(a) != stageOf(b) {
+			return stageOf(a) < stageOf(b)
+		}
+		// the deepest resources first.
+		if da, db := depths[planKeyOf(a)], depths[planKeyOf(b)]; da != db {
+			return da > db
+		}
+		// a volume can only be deleted once its instance is gone.
+		_, aAttached := attachedTo(a)
+		_, bAttached := attachedTo(b)
+		if aAttached != bAttached {
+			return bAttached
+		}
+		if a.Metadata().Type != b.Metadata().Type {
+			return a.Metadata().Type < b.Metadata().Type
+		}
+		return a.Metadata().Name < b.Metadata().Name
+	})
+
+	// before lists the resources that must be deleted before each resource.
+	before := make(map[planKey][]planKey, len(sorted))
+	for _, r := range sorted {
+		if stageOf(r) != stageResources {
+			continue
+		}
+		k := planKeyOf(r)
+		// a child must be deleted before its parent.
+		if parent, ok := parentOf(r); ok {
+			before[parent] = append(before[parent], k)
+		}
+		// an attached resource after the resource it is attached to.
+		if target, ok := attachedTo(r); ok {
+			before[k] = append(before[k], target)
+		}
+		// and a network after the resources attached to it.
+		before[k] = append(before[k], attachmentsOf(r)...)
+	}
+
+	sorted = orderDependencies(sorted, before)
+
+	plan := make(DeletionPlan, 0, len(sorted))
+	indexes := make(map[planKey]int, len(sorted))
+	for i, r := range sorted {
+		indexes[planKeyOf(r)] = i
+		plan = append(plan, DeletionStep{Resource: r})
+	}
+
+	for i, r := range sorted {
+		switch stageOf(r) {
+		case stageResources:
+			for _, k := range before[planKeyOf(r)] {
+				// in a cycle, the dependencies that come after the resource are dropped.
+				if j := indexes[k]; j < i && !slices.Contains(plan[i].DependsOn, j) {
+					plan[i].DependsOn = append(plan[i].DependsOn, j)
+				}
+			}
+			slices.Sort(plan[i].DependsOn)
+		case stageCockpit, stageProject:
+			// wait for all the previous stages.
+			for j := 0; j < i; j++ {
+				if stageOf(sorted[j]) < stageOf(r) {
+					plan[i].DependsOn = append(plan[i].DependsOn, j)
+				}
+			}
+		}
+	}
+
+	return plan
+}
+
+// orderDependencies moves the resources after the ones they depend on,
+// and otherwise keeps their order.
+func orderDependencies(resources []Resource, before map[planKey][]planKey) []Resource {
+	ordered := make([]Resource, 0, len(resources))
+	done := make(map[planKey]bool, len(resources))
+
+	ready := func(r Resource) bool {
+		for _, k := range before[planKeyOf(r)] {
+			if !done[k] {
+				return false
+			}
+		}
+		return true
+	}
+
+	remaining := resources
+	for len(remaining) > 0 {
+		next := 0
+		for i, r := range remaining {
+			// the later stages wait for the whole stage, even when it has a cycle.
+			if stageOf(r) != stageOf(remaining[0]) {
+				break
+			}
+			if ready(r) {
+				next = i
+				break
+			}
+		}
+		// when none is ready, there is a cycle: the first resource breaks it.
+		r := remaining[next]
+		done[planKeyOf(r)] = true
+		ordered = append(ordered, r)
+		remaining = append(remaining[:next:next], remaining[next+1:]...)
+	}
+	return ordered
+}
@@ -0,0 +1,154 @@
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	account_sdk "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	cockpit_sdk "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	fnc_sdk "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	instance_sdk "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	jobs_sdk "github.com/scaleway/scaleway-sdk-go/api/jobs/v1alpha1"
+	registry_sdk "github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	vpc_sdk "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	vpcgw_sdk "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanProjectDeletion(t *testing.T) {
+	const projectID = "project-id"
+
+	namespace := fnc_sdk.Namespace{ID: "namespace-id", Name: "namespace", ProjectID: projectID}
+	definition := jobs_sdk.JobDefinition{ID: "definition-id", Name: "definition", ProjectID: projectID}
+
+	resources := []resource.Resource{
+		scaleway.Project(account_sdk.Project{ID: projectID, Name: "project"}),
+		scaleway.Cockpit(cockpit_sdk.Cockpit{ProjectID: projectID}),
+		scaleway.FunctionNamespace(namespace),
+		scaleway.JobDefinition(definition),
+		scaleway.RegistryNamespace(registry_sdk.Namespace{ID: "registry-id", Name: "registry", ProjectID: projectID}),
+		scaleway.Function{Function: fnc_sdk.Function{ID: "function-id", Name: "function"}, Namespace: namespace},
+		scaleway.JobRun{JobRun: jobs_sdk.JobRun{ID: "run-id", JobDefinitionID: definition.ID}, JobDefinition: definition},
+	}
+
+	plan := resource.PlanProjectDeletion(resources)
+	require.Len(t, plan, len(resources))
+
+	indexOf := func(typ resource.Type) int {
+		for i, step := range plan {
+			if step.Resource.Metadata().Type == typ {
+				return i
+			}
+		}
+		t.Fatalf("no step for %s", typ)
+		return -1
+	}
+
+	t.Run("nested resources are deleted before their parent", func(t *testing.T) {
+		assert.Less(t, indexOf(resource.TypeFunction), indexOf(resource.TypeFunctionNamespace))
+		assert.Less(t, indexOf(resource.TypeJobRun), indexOf(resource.TypeJobDefinition))
+
+		assert.Equal(t, []int{indexOf(resource.TypeFunction)}, plan[indexOf(resource.TypeFunctionNamespace)].DependsOn)
+		assert.Equal(t, []int{indexOf(resource.TypeJobRun)}, plan[indexOf(resource.TypeJobDefinition)].DependsOn)
+		assert.Empty(t, plan[indexOf(resource.TypeRegistryNamespace)].DependsOn)
+	})
+
+	t.Run("cockpit and project are deleted last", func(t *testing.T) {
+		assert.Equal(t, len(plan)-2, indexOf(resource.TypeCockpit))
+		assert.Equal(t, len(plan)-1, indexOf(resource.TypeProject))
+
+		assert.Len(t, plan[indexOf(resource.TypeCockpit)].DependsOn, len(plan)-2)
+		assert.Len(t, plan[indexOf(resource.TypeProject)].DependsOn, len(plan)-1)
+	})
+
+	t.Run("dependencies come first", func(t *testing.T) {
+		for i, step := range plan {
+			for _, dependency := range step.DependsOn {
+				assert.Less(t, dependency, i)
+			}
+		}
+	})
+	t.Run("attached resources are deleted after their instance", func(t *testing.T) {
+		server := &instance_sdk.ServerSummary{ID: "server-id", Name: "server"}
+		resources := []resource.Resource{
+			// the volume is listed first, and would sort first without its attachment.
+			scaleway.Volume(instance_sdk.Volume{ID: "volume-id", Name: "a-volume", Project: projectID, Server: server}),
+			scaleway.FlexibleIP(instance_sdk.IP{ID: "ip-id", Project: projectID, Server: server}),
+			scaleway.Volume(instance_sdk.Volume{ID: "orphan-id", Name: "orphan", Project: projectID}),
+			scaleway.Instance(instance_sdk.Server{ID: "server-id", Name: "server", Project: projectID}),
+		}
+
+		plan := resource.PlanProjectDeletion(resources)
+		require.Len(t, plan, len(resources))
+
+		instance := -1
+		for i, step := range plan {
+			if step.Resource.Metadata().Type == resource.TypeInstance {
+				instance = i
+			}
+		}
+		require.NotEqual(t, -1, instance)
+
+		for i, step := range plan {
+			switch step.Resource.Metadata().ID {
+			case "volume-id", "ip-id":
+				assert.Greater(t, i, instance)
+				assert.Equal(t, []int{instance}, step.DependsOn)
+			case "orphan-id":
+				assert.Empty(t, step.DependsOn)
+			}
+		}
+	})
+
+	t.Run("resources attached to a private network are deleted before it", func(t *testing.T) {
+		vpc := vpc_sdk.VPC{ID: "vpc-id", Name: "vpc", ProjectID: projectID}
+		server := &instance_sdk.ServerSummary{ID: "server-id", Name: "server"}
+		resources := []resource.Resource{
+			scaleway.VPC(vpc),
+			// the private network is nested in its VPC, and would sort first without its attachments.
+			scaleway.PrivateNetwork{
+				PrivateNetwork: vpc_sdk.PrivateNetwork{ID: "pn-id", Name: "pn", ProjectID: projectID, VpcID: vpc.ID},
+				VPC:            vpc,
+				Attached: []resource.NetworkAttachment{
+					{ID: "server-id", Name: "server", Type: resource.TypeInstance},
+					{ID: "gateway-id", Name: "gateway", Type: resource.TypePublicGateway},
+					// not part of the plan, e.g. in another project.
+					{ID: "other-id", Name: "other", Type: resource.TypeInstance},
+				},
+			},
+			scaleway.Volume(instance_sdk.Volume{ID: "volume-id", Name: "volume", Project: projectID, Server: server}),
+			scaleway.Instance(instance_sdk.Server{ID: "server-id", Name: "server", Project: projectID}),
+			scaleway.PublicGateway(vpcgw_sdk.Gateway{ID: "gateway-id", Name: "gateway", ProjectID: projectID}),
+		}
+
+		plan := resource.PlanProjectDeletion(resources)
+		require.Len(t, plan, len(resources))
+
+		indexOf := func(id string) int {
+			for i, step := range plan {
+				if step.Resource.Metadata().ID == id {
+					return i
+				}
+			}
+			t.Fatalf("no step for %s", id)
+			return -1
+		}
+
+		network := indexOf("pn-id")
+		assert.Less(t, indexOf("server-id"), network)
+		assert.Less(t, indexOf("gateway-id"), network)
+		assert.Less(t, network, indexOf("vpc-id"))
+
+		assert.ElementsMatch(t, []int{indexOf("server-id"), indexOf("gateway-id")}, plan[network].DependsOn)
+		assert.Equal(t, []int{network}, plan[indexOf("vpc-id")].DependsOn)
+		assert.Equal(t, []int{indexOf("server-id")}, plan[indexOf("volume-id")].DependsOn)
+
+		for i, step := range plan {
+			for _, dependency := range step.DependsOn {
+				assert.Less(t, dependency, i)
+			}
+		}
+	})
+}
@@ -3,6 +3,7 @@ package resource
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
 // Indexer is a very simple wrapper around a Storer and a Searcher.
@@ -18,12 +19,17 @@ type Indexer interface {
 type Index struct {
 	Store  Storer
 	Search Searcher
+
+	mutex       *sync.Mutex
+	subscribers map[*Subscription]struct{}
 }
 
 func NewIndex(store Storer, search Searcher) *Index {
 	return &Index{
-		Store:  store,
-		Search: search,
+		Store:       store,
+		Search:      search,
+		mutex:       &sync.Mutex{},
+		subscribers: make(map[*Subscription]struct{}),
 	}
 }
 
@@ -34,6 +40,7 @@ func (i *Index) Index(ctx context.Context, r Resource) error {
 	if err := i.Search.Index(r); err != nil {
 		return fmt.Errorf("indexer: failed to index resource: %w", err)
 	}
+	i.publish(IndexEvent{Kind: IndexEventUpsert, Resource: r})
 	return nil
 }
 
@@ -44,5 +51,101 @@ func (i *Index) Deindex(ctx context.Context, r Resource) error {
 	if err := i.Search.Deindex(r); err != nil {
 		return fmt.Errorf("indexer: failed to deindex resource: %w", err)
 	}
+	i.publish(IndexEvent{Kind: IndexEventDelete, Resource: r})
 	return nil
 }
+
+type IndexEventKind int
+
+const (
+	// IndexEventUpsert is published when a resource is created or updated.
+	IndexEventUpsert IndexEventKind = iota
+	// IndexEventDelete is published when a resource is deleted.
+	IndexEventDelete
+)
+
+// IndexEvent is published to the subscribers when the index changes.
+type IndexEvent struct {
+	Kind     IndexEventKind
+	Resource Resource
+}
+
+// Subscribe returns a subscription to the changes of the index.
+// The subscription must be closed once it is not needed anymore.
+func (i *Index) Subscribe() *Subscription {
+	s := &Subscription{
+		index:  i,
+		mutex:  &sync.Mutex{},
+		notify: make(chan struct{}, 1),
+	}
+
+	i.mutex.Lock()
+	i.subscribers[s] = struct{}{}
+	i.mutex.Unlock()
+
+	return s
+}
+
+func (i *Index) publish(e IndexEvent) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	for s := range i.subscribers {
+		s.push(e)
+	}
+}
+
+// Subscription receives the changes of an index.
+// The events are queued until they are read, so that publishing never blocks the indexing.
+type Subscription struct {
+	index *Index
+
+	mutex   *sync.Mutex
+	pending []IndexEvent
+	notify  chan struct{}
+}
+
+func (s *Subscription) push(e IndexEvent) {
+	s.mutex.Lock()
+	s.pending = append(s.pending, e)
+	s.mutex.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+		// a notification is already pending.
+	}
+}
+
+// Next waits for events and returns all the events published since the last call, in order.
+func (s *Subscription) Next(ctx context.Context) ([]IndexEvent, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.notify:
+		}
+
+		// the events may have been read along with a previous notification.
+		if events := s.drain(); len(events) > 0 {
+			return events, nil
+		}
+	}
+}
+
+func (s *Subscription) drain() []IndexEvent {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	events := s.pending
+	s.pending = nil
+	return events
+}
+
+// Close stops receiving events.
+func (s *Subscription) Close() {
+	s.index.mutex.Lock()
+	defer s.index.mutex.Unlock()
+
+	delete(s.index.subscribers, s)
+}
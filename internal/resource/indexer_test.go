@@ -0,0 +1,57 @@
+package resource_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/search/bleve"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Subscribe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	store := testhelpers.NewStoreFromResources(t, nil)
+	search, err := bleve.NewSearch(nil)
+	require.NoError(t, err)
+
+	index := resource.NewIndex(store, search)
+
+	sub := index.Subscribe()
+	defer sub.Close()
+
+	r := &testhelpers.MockResource{
+		MetadataValue: resource.Metadata{
+			ID:       "resource-id",
+			Locality: resource.Region(scw.RegionFrPar),
+		},
+	}
+
+	require.NoError(t, index.Index(ctx, r))
+	require.NoError(t, index.Deindex(ctx, r))
+
+	// the events are batched until they are read.
+	events, err := sub.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, resource.IndexEventUpsert, events[0].Kind)
+	assert.Equal(t, resource.IndexEventDelete, events[1].Kind)
+	assert.Equal(t, r, events[1].Resource)
+
+	t.Run("closed subscriptions do not receive events", func(t *testing.T) {
+		sub.Close()
+		require.NoError(t, index.Index(ctx, r))
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := sub.Next(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,130 @@
+package resource
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+type Log struct {
+	Timestamp time.Time
+	Line      string
+
+	// Labels are the labels of the stream of the log, such as resource_name.
+	Labels map[string]string
+	// Fields are the top-level fields of a structured log, such as a JSON line.
+	// Nil if the line is not structured.
+	Fields map[string]string
+
+	// ResourceID is the ID of the resource of the log, to tell apart the logs of several resources.
+	ResourceID string
+}
+
+// NewLog creates a log, parsing the fields of the line if it is a JSON object.
+func NewLog(timestamp time.Time, line string, labels map[string]string) Log {
+	return Log{
+		Timestamp: timestamp,
+		Line:      line,
+		Labels:    labels,
+		Fields:    ParseLogFields(line),
+	}
+}
+
+// ParseLogFields returns the top-level fields of a JSON object, or nil if the line is not one.
+// The strings are unquoted, the other values are kept as JSON.
+func ParseLogFields(line string) map[string]string {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(trimmed), &raw); err != nil {
+		return nil
+	}
+
+	fields := make(map[string]string, len(raw))
+	for name, value := range raw {
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			fields[name] = s
+			continue
+		}
+		fields[name] = string(value)
+	}
+	return fields
+}
+
+// Structured returns true if the line of the log is a JSON object.
+func (l Log) Structured() bool {
+	return l.Fields != nil
+}
+
+// Field returns the value of a field of the log, or of one of its labels.
+func (l Log) Field(name string) (string, bool) {
+	if v, ok := l.Fields[name]; ok {
+		return v, true
+	}
+	v, ok := l.Labels[name]
+	return v, ok
+}
+
+// nolint:gochecknoglobals
+var (
+	// messageFields are the fields that usually hold the message of a structured log.
+	messageFields = []string{"message", "msg"}
+	// levelFields are the fields and labels that usually hold the level of a log.
+	levelFields = []string{"level", "severity", "lvl", "log_level", "detected_level"}
+)
+
+// Message returns the message of a structured log, or the whole line.
+func (l Log) Message() string {
+	for _, name := range messageFields {
+		if v, ok := l.Fields[name]; ok {
+			return v
+		}
+	}
+	return l.Line
+}
+
+type LogLevel string
+
+const (
+	LogLevelUnknown LogLevel = ""
+	LogLevelDebug   LogLevel = "debug"
+	LogLevelInfo    LogLevel = "info"
+	LogLevelWarning LogLevel = "warning"
+	LogLevelError   LogLevel = "error"
+)
+
+// Level returns the level of the log, from its fields or its labels.
+func (l Log) Level() LogLevel {
+	for _, name := range levelFields {
+		if v, ok := l.Field(name); ok {
+			if level := ParseLogLevel(v); level != LogLevelUnknown {
+				return level
+			}
+		}
+	}
+	return LogLevelUnknown
+}
+
+// ParseLogLevel parses the usual names of the log levels, such as "WARN" or "err".
+func ParseLogLevel(s string) LogLevel {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case s == "":
+		return LogLevelUnknown
+	case strings.HasPrefix(s, "debug"), strings.HasPrefix(s, "trace"):
+		return LogLevelDebug
+	case strings.HasPrefix(s, "info"), s == "notice":
+		return LogLevelInfo
+	case strings.HasPrefix(s, "warn"):
+		return LogLevelWarning
+	case strings.HasPrefix(s, "err"), strings.HasPrefix(s, "crit"),
+		s == "fatal", s == "panic", s == "alert", s == "emergency":
+		return LogLevelError
+	default:
+		return LogLevelUnknown
+	}
+}
@@ -0,0 +1,65 @@
+package resource_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLog(t *testing.T) {
+	now := time.Now()
+
+	t.Run("json lines are parsed", func(t *testing.T) {
+		l := resource.NewLog(now, `{"message":"hello","level":"WARN","status":404,"request":{"id":"abc"}}`, nil)
+
+		assert.True(t, l.Structured())
+		assert.Equal(t, map[string]string{
+			"message": "hello",
+			"level":   "WARN",
+			"status":  "404",
+			"request": `{"id":"abc"}`,
+		}, l.Fields)
+		assert.Equal(t, "hello", l.Message())
+		assert.Equal(t, resource.LogLevelWarning, l.Level())
+	})
+
+	t.Run("plain lines are kept as is", func(t *testing.T) {
+		for _, line := range []string{"hello", "{not json", `["an", "array"]`} {
+			l := resource.NewLog(now, line, nil)
+
+			assert.False(t, l.Structured(), line)
+			assert.Equal(t, line, l.Message())
+			assert.Equal(t, resource.LogLevelUnknown, l.Level())
+		}
+	})
+
+	t.Run("the level can come from the labels", func(t *testing.T) {
+		l := resource.NewLog(now, "hello", map[string]string{"detected_level": "error"})
+
+		assert.Equal(t, resource.LogLevelError, l.Level())
+		v, ok := l.Field("detected_level")
+		assert.True(t, ok)
+		assert.Equal(t, "error", v)
+	})
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := map[string]resource.LogLevel{
+		"":         resource.LogLevelUnknown,
+		"DEBUG":    resource.LogLevelDebug,
+		"trace":    resource.LogLevelDebug,
+		"Info":     resource.LogLevelInfo,
+		"warn":     resource.LogLevelWarning,
+		"WARNING":  resource.LogLevelWarning,
+		"err":      resource.LogLevelError,
+		"critical": resource.LogLevelError,
+		"fatal":    resource.LogLevelError,
+		"stdout":   resource.LogLevelUnknown,
+	}
+
+	for s, want := range tests {
+		assert.Equal(t, want, resource.ParseLogLevel(s), s)
+	}
+}
@@ -5,12 +5,77 @@ import (
 	"time"
 )
 
-type Log struct {
+// LogsDirection is the order in which the logs are selected when there are more than the limit.
+type LogsDirection int
+
+const (
+	// LogsBackward selects the most recent logs first.
+	LogsBackward LogsDirection = iota
+	// LogsForward selects the oldest logs first.
+	LogsForward
+)
+
+// LogsQuery selects the logs to return.
+type LogsQuery struct {
+	// Start is the beginning of the time range.
+	// If zero, the monitor picks a default.
+	Start time.Time
+
+	// End is the end of the time range.
+	// If zero, the logs are returned up to now.
+	End time.Time
+
+	// Limit is the maximum number of logs to return.
+	// If zero, the monitor picks a default.
+	Limit int
+
+	// Direction is used to pick the logs to return when there are more than the limit.
+	Direction LogsDirection
+
+	// Filter is a LogQL pipeline appended to the selector of the resource,
+	// such as `|= "error"`. If empty, all the logs are returned.
+	Filter string
+}
+
+// Metric is a measure of the health of a resource.
+type Metric string
+
+const (
+	MetricCPU         Metric = "cpu"
+	MetricMemory      Metric = "memory"
+	MetricRequestRate Metric = "request_rate"
+	MetricErrorRate   Metric = "error_rate"
+)
+
+// Sample is the value of a metric at a point in time.
+type Sample struct {
 	Timestamp time.Time
-	Line      string
+	Value     float64
+}
+
+// Series holds the samples of a metric, from the oldest to the most recent.
+type Series struct {
+	Metric Metric
+	// Unit is the unit of the values, such as "bytes" or "%".
+	Unit    string
+	Samples []Sample
+}
+
+// MetricsQuery selects the samples of the metrics to return.
+type MetricsQuery struct {
+	// Start and End are the bounds of the time range.
+	Start time.Time
+	End   time.Time
+
+	// Step is the duration between two samples.
+	Step time.Duration
 }
 
 type Monitorer interface {
-	// Logs returns the logs of a resource.
-	Logs(ctx context.Context, r Resource) ([]Log, error)
+	// Logs returns the logs of a resource, from the oldest to the most recent.
+	Logs(ctx context.Context, r Resource, query LogsQuery) ([]Log, error)
+
+	// Metrics returns a series for each of the metrics of a resource, in the order of the Metric constants.
+	// The metrics that are not available for the type of the resource are omitted.
+	Metrics(ctx context.Context, r Resource, query MetricsQuery) ([]Series, error)
 }
@@ -43,6 +43,9 @@ type CockpitMetadata struct {
 	// CanViewLogs is true if the logs associated with the resource can be viewed Scaleway Cockpit.
 	CanViewLogs bool
 
+	// CanViewMetrics is true if the metrics of the resource can be viewed in Scaleway Cockpit.
+	CanViewMetrics bool
+
 	// ResourceName is the name of the resource in Scaleway Cockpit.
 	ResourceName string
 
@@ -73,6 +76,11 @@ type Action struct {
 	// It should return an error if the action failed.
 	// The index is provided to add or delete resources.
 	Do func(ctx context.Context, index Indexer, client *scw.Client) error
+
+	// Report is set instead of Do for the actions that show something, such as the health of the servers of a backend.
+	// It returns the text to display.
+	// These actions cannot be run on several resources at once.
+	Report func(ctx context.Context, client *scw.Client) (string, error)
 }
 
 type Actionable interface {
@@ -81,3 +89,74 @@ type Actionable interface {
 	// Actions returns the list of actions that can be performed on the resource.
 	Actions() []Action
 }
+
+// Nested is implemented by the resources that belong to another resource,
+// such as a function in a namespace.
+type Nested interface {
+	Resource
+
+	// Parent returns the resource the resource belongs to.
+	Parent() Resource
+}
+
+// Attachable is implemented by the resources that can be attached to another resource,
+// such as a volume to an instance. Unlike a nested resource, it outlives the resource it is attached to.
+type Attachable interface {
+	Resource
+
+	// AttachedTo returns the resource the resource is attached to, or nil if it is not attached.
+	AttachedTo() Resource
+}
+
+// Networked is implemented by the networks other resources are attached to, such as a Private Network.
+type Networked interface {
+	Resource
+
+	// Attachments returns the resources attached to the network when it was discovered.
+	Attachments() []NetworkAttachment
+}
+
+// NetworkAttachment is a resource attached to a network.
+type NetworkAttachment struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type Type   `json:"type"`
+	// Locality is the zone or the region of the resource.
+	Locality string `json:"locality"`
+	// Address is the address of the resource in the network, empty if it is not known.
+	Address string `json:"address"`
+}
+
+// maxNestingDepth bounds the walk of the parents, in case of a cycle.
+const maxNestingDepth = 8
+
+// Descendants returns the resources that belong to the parent, directly or not.
+// All the resources of a project belong to it.
+func Descendants(parent Resource, resources []Resource) []Resource {
+	metadata := parent.Metadata()
+
+	isDescendant := func(r Resource) bool {
+		if metadata.Type == TypeProject {
+			return r.Metadata().ProjectID == metadata.ID && r.Metadata().Type != TypeProject
+		}
+		for depth := 0; depth < maxNestingDepth; depth++ {
+			nested, ok := r.(Nested)
+			if !ok || nested.Parent() == nil {
+				return false
+			}
+			r = nested.Parent()
+			if r.Metadata().ID == metadata.ID && r.Metadata().Type == metadata.Type {
+				return true
+			}
+		}
+		return false
+	}
+
+	var descendants []Resource
+	for _, r := range resources {
+		if isDescendant(r) {
+			descendants = append(descendants, r)
+		}
+	}
+	return descendants
+}
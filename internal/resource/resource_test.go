@@ -0,0 +1,42 @@
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	account_sdk "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	fnc_sdk "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	registry_sdk "github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescendants(t *testing.T) {
+	const projectID = "project-id"
+
+	project := scaleway.Project(account_sdk.Project{ID: projectID, Name: "project"})
+	namespace := fnc_sdk.Namespace{ID: "namespace-id", Name: "namespace", ProjectID: projectID}
+	other := fnc_sdk.Namespace{ID: "other-id", Name: "other", ProjectID: projectID}
+	function := scaleway.Function{Function: fnc_sdk.Function{ID: "function-id", Name: "function"}, Namespace: namespace}
+	registry := scaleway.RegistryNamespace(registry_sdk.Namespace{ID: "registry-id", Name: "registry", ProjectID: projectID})
+
+	resources := []resource.Resource{
+		project,
+		scaleway.FunctionNamespace(namespace),
+		function,
+		scaleway.Function{Function: fnc_sdk.Function{ID: "other-function-id", Name: "other-function"}, Namespace: other},
+		registry,
+	}
+
+	names := func(resources []resource.Resource) []string {
+		var names []string
+		for _, r := range resources {
+			names = append(names, r.Metadata().Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"function"}, names(resource.Descendants(scaleway.FunctionNamespace(namespace), resources)))
+	assert.Equal(t, []string{"namespace", "function", "other-function", "registry"}, names(resource.Descendants(project, resources)))
+	assert.Empty(t, resource.Descendants(registry, resources))
+}
@@ -0,0 +1,20 @@
+package resource
+
+import "time"
+
+// Revision is a version of a resource.
+// A new revision is saved each time the resource changes.
+type Revision struct {
+	// Revision identifies the revision. Later revisions have greater numbers.
+	Revision int64
+
+	// Status is the status of the resource at this revision.
+	// Maybe nil if not available.
+	Status *Status
+
+	// JSON is the JSON representation of the resource at this revision.
+	JSON string
+
+	// CreatedAt is the date the revision was saved.
+	CreatedAt time.Time
+}
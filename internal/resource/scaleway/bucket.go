@@ -0,0 +1,62 @@
+package scaleway
+
+import (
+	"context"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/objectstorage"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// Bucket is a bucket of Object Storage.
+// Object Storage is S3-compatible, so there is no type for it in the SDK.
+type Bucket struct {
+	Name      string     `json:"name"`
+	ProjectID string     `json:"project_id"`
+	Region    scw.Region `json:"region"`
+	CreatedAt *time.Time `json:"created_at"`
+	// Endpoint is the endpoint of Object Storage the bucket was discovered with.
+	Endpoint string `json:"endpoint"`
+}
+
+func (b Bucket) Metadata() resource.Metadata {
+	return resource.Metadata{
+		// the names of the buckets are only unique within a region.
+		ID:        string(b.Region) + "/" + b.Name,
+		Name:      b.Name,
+		ProjectID: b.ProjectID,
+		CreatedAt: b.CreatedAt,
+		Type:      resource.TypeBucket,
+		Locality:  resource.Region(b.Region),
+	}
+}
+
+func (b Bucket) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+// Client returns a client of Object Storage, to browse the objects of the bucket.
+func (b Bucket) Client(client *scw.Client) (*objectstorage.Client, error) {
+	endpoint := b.Endpoint
+	if endpoint == "" {
+		endpoint = objectstorage.Endpoint(b.Region)
+	}
+	return objectstorage.NewClient(client, endpoint, b.Region, b.ProjectID)
+}
+
+// Delete deletes the bucket. Like with the S3 API, the bucket must be empty.
+func (b Bucket) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	s3, err := b.Client(client)
+	if err != nil {
+		return err
+	}
+
+	if err := s3.DeleteBucket(ctx, b.Name); err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, b)
+}
@@ -28,13 +28,18 @@ func (c Container) Metadata() resource.Metadata {
 	}
 }
 
+func (c Container) Parent() resource.Resource {
+	return ContainerNamespace(c.Namespace)
+}
+
 func (c Container) CockpitMetadata() resource.CockpitMetadata {
 	s := strings.TrimPrefix(c.DomainName, "https://")
 	resourceName := strings.Split(s, ".")[0]
 	return resource.CockpitMetadata{
-		CanViewLogs:  true,
-		ResourceName: resourceName,
-		ResourceType: "serverless_container",
+		CanViewLogs:    true,
+		CanViewMetrics: true,
+		ResourceName:   resourceName,
+		ResourceType:   "serverless_container",
 	}
 }
 
@@ -0,0 +1,80 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type FlexibleIP sdk.IP
+
+func (ip FlexibleIP) Metadata() resource.Metadata {
+	description := orphaned
+	if ip.Server != nil {
+		description = "attached to " + ip.Server.Name
+	}
+
+	return resource.Metadata{
+		ID:          ip.ID,
+		Name:        ip.Address.String(),
+		ProjectID:   ip.Project,
+		Status:      statusPtr(ip.State),
+		Description: &description,
+		Tags:        ip.Tags,
+		Type:        resource.TypeFlexibleIP,
+		Locality:    resource.Zone(ip.Zone),
+	}
+}
+
+func (ip FlexibleIP) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (ip FlexibleIP) AttachedTo() resource.Resource {
+	if ip.Server == nil {
+		return nil
+	}
+	return attachedInstance(ip.Server, ip.Project, ip.Zone)
+}
+
+func (ip FlexibleIP) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	err := api.DeleteIP(&sdk.DeleteIPRequest{
+		IP:   ip.ID,
+		Zone: ip.Zone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, ip)
+}
+
+func (ip FlexibleIP) Actions() []resource.Action {
+	if ip.Server == nil {
+		return nil
+	}
+
+	return []resource.Action{
+		{
+			Name: "Detach",
+			Do: func(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+				api := sdk.NewAPI(client)
+				r, err := api.UpdateIP(&sdk.UpdateIPRequest{
+					Zone:   ip.Zone,
+					IP:     ip.ID,
+					Server: &sdk.NullableStringValue{Null: true},
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+
+				return index.Index(ctx, FlexibleIP(*r.IP))
+			},
+		},
+	}
+}
@@ -28,13 +28,18 @@ func (f Function) Metadata() resource.Metadata {
 	}
 }
 
+func (f Function) Parent() resource.Resource {
+	return FunctionNamespace(f.Namespace)
+}
+
 func (f Function) CockpitMetadata() resource.CockpitMetadata {
 	s := strings.TrimPrefix(f.DomainName, "https://")
 	resourceName := strings.Split(s, ".")[0]
 	return resource.CockpitMetadata{
-		CanViewLogs:  true,
-		ResourceName: resourceName,
-		ResourceType: "serverless_function",
+		CanViewLogs:    true,
+		CanViewMetrics: true,
+		ResourceName:   resourceName,
+		ResourceType:   "serverless_function",
 	}
 }
 
@@ -27,6 +27,10 @@ func (run JobRun) Metadata() resource.Metadata {
 	}
 }
 
+func (run JobRun) Parent() resource.Resource {
+	return JobDefinition(run.JobDefinition)
+}
+
 func (run JobRun) CockpitMetadata() resource.CockpitMetadata {
 	return resource.CockpitMetadata{
 		CanViewLogs:  true,
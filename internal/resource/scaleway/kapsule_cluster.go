@@ -26,9 +26,10 @@ func (c KapsuleCluster) Metadata() resource.Metadata {
 
 func (c KapsuleCluster) CockpitMetadata() resource.CockpitMetadata {
 	return resource.CockpitMetadata{
-		CanViewLogs:  true,
-		ResourceName: c.Name,
-		ResourceType: "kubernetes_cluster",
+		CanViewLogs:    true,
+		CanViewMetrics: true,
+		ResourceName:   c.Name,
+		ResourceType:   "kubernetes_cluster",
 	}
 }
 
@@ -0,0 +1,162 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// kapsulePollTimeout bounds the polling of a pool or a node after an operation,
+// as a node that never becomes ready would otherwise be polled forever.
+const kapsulePollTimeout = time.Hour
+
+type KapsuleNode struct {
+	sdk.Node `json:"node"`
+	Pool     sdk.Pool    `json:"pool"`
+	Cluster  sdk.Cluster `json:"cluster"`
+}
+
+func (n KapsuleNode) Metadata() resource.Metadata {
+	description := n.Pool.NodeType
+	if n.ErrorMessage != nil && *n.ErrorMessage != "" {
+		description += ", " + *n.ErrorMessage
+	}
+
+	return resource.Metadata{
+		ID:          n.Node.ID,
+		Name:        n.Node.Name,
+		ProjectID:   n.Cluster.ProjectID,
+		Status:      statusPtr(n.Node.Status),
+		Description: &description,
+		CreatedAt:   n.Node.CreatedAt,
+		Type:        resource.TypeKapsuleNode,
+		Locality:    resource.Zone(n.Pool.Zone),
+	}
+}
+
+func (n KapsuleNode) Parent() resource.Resource {
+	return KapsulePool{Pool: n.Pool, Cluster: n.Cluster}
+}
+
+func (n KapsuleNode) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+// Delete is a no-op because the nodes are managed by their pool.
+// A broken node can be replaced instead.
+func (n KapsuleNode) Delete(_ context.Context, _ resource.Indexer, _ *scw.Client) error {
+	return nil
+}
+
+func (n KapsuleNode) Actions() []resource.Action {
+	return []resource.Action{
+		{
+			Name: "Reboot",
+			Do: func(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+				api := sdk.NewAPI(client)
+				r, err := api.RebootNode(&sdk.RebootNodeRequest{
+					NodeID: n.Node.ID,
+					Region: n.Node.Region,
+				})
+				if err != nil {
+					return err
+				}
+
+				return n.track(ctx, index, client, r)
+			},
+		},
+		{
+			Name: "Replace",
+			Do: func(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+				api := sdk.NewAPI(client)
+				r, err := api.ReplaceNode(&sdk.ReplaceNodeRequest{
+					NodeID: n.Node.ID,
+					Region: n.Node.Region,
+				})
+				if err != nil {
+					return err
+				}
+
+				return n.track(ctx, index, client, r)
+			},
+		},
+	}
+}
+
+// track indexes the node returned by an operation, and polls it until the operation is finished.
+func (n KapsuleNode) track(ctx context.Context, index resource.Indexer, client *scw.Client, r *sdk.Node) error {
+	updated := KapsuleNode{
+		Node:    *r,
+		Pool:    n.Pool,
+		Cluster: n.Cluster,
+	}
+
+	if err := index.Index(ctx, updated); err != nil {
+		return err
+	}
+
+	go pollInBackground(ctx, updated, func(ctx context.Context) error {
+		return updated.pollUntilSettled(ctx, index, client)
+	})
+
+	return nil
+}
+
+// pollInBackground polls a pool or a node until its operation is finished.
+// The action has already returned, so a failure can only be logged.
+func pollInBackground(ctx context.Context, r resource.Resource, poll func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(ctx, kapsulePollTimeout)
+	defer cancel()
+
+	if err := poll(ctx); err != nil {
+		metadata := r.Metadata()
+		slog.Error("kapsule: failed to refresh the status after an action",
+			slog.String("id", metadata.ID),
+			slog.String("type", metadata.Type.String()),
+			slog.String("err", err.Error()))
+	}
+}
+
+func (n KapsuleNode) pollUntilSettled(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	for {
+		r, err := api.GetNode(&sdk.GetNodeRequest{
+			NodeID: n.Node.ID,
+			Region: n.Node.Region,
+		})
+		// a replaced node is deleted once its replacement is created.
+		var notFoundErr *scw.ResourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return index.Deindex(ctx, n)
+		}
+		if err != nil {
+			return err
+		}
+
+		n.Node = *r
+		if err := index.Index(ctx, n); err != nil {
+			return err
+		}
+
+		// a rebooted node is not ready for a while, so it is not a final status.
+		if n.Node.Status == sdk.NodeStatusReady ||
+			n.Node.Status == sdk.NodeStatusLocked ||
+			n.Node.Status == sdk.NodeStatusCreationError ||
+			n.Node.Status == sdk.NodeStatusDeleted {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
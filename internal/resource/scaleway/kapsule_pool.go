@@ -0,0 +1,160 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type KapsulePool struct {
+	sdk.Pool `json:"pool"`
+	Cluster  sdk.Cluster `json:"cluster"`
+}
+
+func (p KapsulePool) Metadata() resource.Metadata {
+	description := fmt.Sprintf("%s, %d nodes", p.NodeType, p.Size)
+	if p.Autoscaling {
+		description += fmt.Sprintf(", autoscaling from %d to %d", p.MinSize, p.MaxSize)
+	}
+	description += ", " + p.Pool.Version
+
+	return resource.Metadata{
+		ID:          p.Pool.ID,
+		Name:        p.Pool.Name,
+		ProjectID:   p.Cluster.ProjectID,
+		Status:      statusPtr(p.Pool.Status),
+		Description: &description,
+		CreatedAt:   p.Pool.CreatedAt,
+		Tags:        p.Pool.Tags,
+		Type:        resource.TypeKapsulePool,
+		Locality:    resource.Zone(p.Zone),
+	}
+}
+
+func (p KapsulePool) Parent() resource.Resource {
+	return KapsuleCluster(p.Cluster)
+}
+
+func (p KapsulePool) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (p KapsulePool) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	_, err := api.DeletePool(&sdk.DeletePoolRequest{
+		PoolID: p.Pool.ID,
+		Region: p.Pool.Region,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, p)
+}
+
+func (p KapsulePool) Actions() []resource.Action {
+	var actions []resource.Action
+
+	// the size of an autoscaled pool is managed by the autoscaler.
+	if !p.Autoscaling {
+		actions = append(actions, p.scaleAction("Scale up", p.Size+1))
+		if p.Size > 0 {
+			actions = append(actions, p.scaleAction("Scale down", p.Size-1))
+		}
+	}
+
+	if p.Pool.Version != p.Cluster.Version {
+		actions = append(actions, resource.Action{
+			Name: "Upgrade to " + p.Cluster.Version,
+			Do: func(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+				api := sdk.NewAPI(client)
+				r, err := api.UpgradePool(&sdk.UpgradePoolRequest{
+					PoolID:  p.Pool.ID,
+					Region:  p.Pool.Region,
+					Version: p.Cluster.Version,
+				})
+				if err != nil {
+					return err
+				}
+
+				return p.track(ctx, index, client, r)
+			},
+		})
+	}
+
+	return actions
+}
+
+func (p KapsulePool) scaleAction(name string, size uint32) resource.Action {
+	return resource.Action{
+		Name: fmt.Sprintf("%s to %d nodes", name, size),
+		Do: func(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+			api := sdk.NewAPI(client)
+			r, err := api.UpdatePool(&sdk.UpdatePoolRequest{
+				PoolID: p.Pool.ID,
+				Region: p.Pool.Region,
+				Size:   &size,
+			})
+			if err != nil {
+				return err
+			}
+
+			return p.track(ctx, index, client, r)
+		},
+	}
+}
+
+// track indexes the pool returned by an operation, and polls it until the operation is finished.
+func (p KapsulePool) track(ctx context.Context, index resource.Indexer, client *scw.Client, r *sdk.Pool) error {
+	updated := KapsulePool{
+		Pool:    *r,
+		Cluster: p.Cluster,
+	}
+
+	if err := index.Index(ctx, updated); err != nil {
+		return err
+	}
+
+	go pollInBackground(ctx, updated, func(ctx context.Context) error {
+		return updated.pollUntilSettled(ctx, index, client)
+	})
+
+	return nil
+}
+
+func (p KapsulePool) pollUntilSettled(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	for {
+		r, err := api.GetPool(&sdk.GetPoolRequest{
+			PoolID: p.Pool.ID,
+			Region: p.Pool.Region,
+		})
+		if err != nil {
+			return err
+		}
+
+		p.Pool = *r
+		if err := index.Index(ctx, p); err != nil {
+			return err
+		}
+
+		if p.Pool.Status == sdk.PoolStatusReady ||
+			p.Pool.Status == sdk.PoolStatusWarning ||
+			p.Pool.Status == sdk.PoolStatusLocked ||
+			p.Pool.Status == sdk.PoolStatusDeleted {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type LoadBalancer sdk.LB
+
+func (lb LoadBalancer) Metadata() resource.Metadata {
+	return resource.Metadata{
+		ID:          lb.ID,
+		Name:        lb.Name,
+		ProjectID:   lb.ProjectID,
+		Status:      statusPtr(lb.Status),
+		Description: &lb.Description,
+		CreatedAt:   lb.CreatedAt,
+		Tags:        lb.Tags,
+		Type:        resource.TypeLoadBalancer,
+		Locality:    resource.Zone(lb.Zone),
+	}
+}
+
+func (lb LoadBalancer) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs:  true,
+		ResourceID:   lb.ID,
+		ResourceType: "load_balancer",
+	}
+}
+
+func (lb LoadBalancer) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewZonedAPI(client)
+	err := api.DeleteLB(&sdk.ZonedAPIDeleteLBRequest{
+		Zone: lb.Zone,
+		LBID: lb.ID,
+		// the flexible IPs are kept, as they may be used again for another Load Balancer.
+		ReleaseIP: false,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, lb)
+}
+
+func (lb LoadBalancer) Actions() []resource.Action {
+	return []resource.Action{
+		{
+			Name: "Show backend health",
+			Report: func(ctx context.Context, client *scw.Client) (string, error) {
+				api := sdk.NewZonedAPI(client)
+				backends, err := api.ListBackends(&sdk.ZonedAPIListBackendsRequest{
+					Zone: lb.Zone,
+					LBID: lb.ID,
+				}, scw.WithAllPages(), scw.WithContext(ctx))
+				if err != nil {
+					return "", err
+				}
+
+				stats, err := listBackendStats(ctx, client, lb.Zone, lb.ID, nil)
+				if err != nil {
+					return "", err
+				}
+
+				return renderBackendHealth(backends.Backends, stats), nil
+			},
+		},
+	}
+}
+
+type LBFrontend sdk.Frontend
+
+func (f LBFrontend) Metadata() resource.Metadata {
+	description := fmt.Sprintf("port %d", f.InboundPort)
+	if f.Backend != nil {
+		description += " → " + f.Backend.Name
+	}
+
+	return resource.Metadata{
+		ID:          f.ID,
+		Name:        f.Name,
+		ProjectID:   f.loadBalancer().ProjectID,
+		Description: &description,
+		CreatedAt:   f.CreatedAt,
+		Type:        resource.TypeLBFrontend,
+		Locality:    resource.Zone(f.loadBalancer().Zone),
+	}
+}
+
+func (f LBFrontend) loadBalancer() sdk.LB {
+	if f.LB == nil {
+		return sdk.LB{}
+	}
+	return *f.LB
+}
+
+func (f LBFrontend) Parent() resource.Resource {
+	if f.LB == nil {
+		return nil
+	}
+	return LoadBalancer(*f.LB)
+}
+
+func (f LBFrontend) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (f LBFrontend) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewZonedAPI(client)
+	err := api.DeleteFrontend(&sdk.ZonedAPIDeleteFrontendRequest{
+		Zone:       f.loadBalancer().Zone,
+		FrontendID: f.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, f)
+}
+
+type LBBackend sdk.Backend
+
+func (b LBBackend) Metadata() resource.Metadata {
+	description := fmt.Sprintf("%s:%d, %d servers", b.ForwardProtocol, b.ForwardPort, len(b.Pool))
+
+	return resource.Metadata{
+		ID:          b.ID,
+		Name:        b.Name,
+		ProjectID:   b.loadBalancer().ProjectID,
+		Description: &description,
+		CreatedAt:   b.CreatedAt,
+		Type:        resource.TypeLBBackend,
+		Locality:    resource.Zone(b.loadBalancer().Zone),
+	}
+}
+
+func (b LBBackend) loadBalancer() sdk.LB {
+	if b.LB == nil {
+		return sdk.LB{}
+	}
+	return *b.LB
+}
+
+func (b LBBackend) Parent() resource.Resource {
+	if b.LB == nil {
+		return nil
+	}
+	return LoadBalancer(*b.LB)
+}
+
+func (b LBBackend) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (b LBBackend) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewZonedAPI(client)
+	err := api.DeleteBackend(&sdk.ZonedAPIDeleteBackendRequest{
+		Zone:      b.loadBalancer().Zone,
+		BackendID: b.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, b)
+}
+
+func (b LBBackend) Actions() []resource.Action {
+	return []resource.Action{
+		{
+			Name: "Show health",
+			Report: func(ctx context.Context, client *scw.Client) (string, error) {
+				lb := b.loadBalancer()
+				stats, err := listBackendStats(ctx, client, lb.Zone, lb.ID, &b.ID)
+				if err != nil {
+					return "", err
+				}
+
+				backend := sdk.Backend(b)
+				return renderBackendHealth([]*sdk.Backend{&backend}, stats), nil
+			},
+		},
+	}
+}
+
+func listBackendStats(ctx context.Context, client *scw.Client, zone scw.Zone, lbID string, backendID *string) ([]*sdk.BackendServerStats, error) {
+	api := sdk.NewZonedAPI(client)
+	resp, err := api.ListBackendStats(&sdk.ZonedAPIListBackendStatsRequest{
+		Zone:      zone,
+		LBID:      lbID,
+		BackendID: backendID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return resp.BackendServersStats, nil
+}
+
+// renderBackendHealth renders the state and the last health check of each server, grouped by backend.
+// A Load Balancer runs on several instances, which each check the servers.
+func renderBackendHealth(backends []*sdk.Backend, stats []*sdk.BackendServerStats) string {
+	statsPerBackend := make(map[string][]*sdk.BackendServerStats)
+	for _, s := range stats {
+		if s != nil {
+			statsPerBackend[s.BackendID] = append(statsPerBackend[s.BackendID], s)
+		}
+	}
+
+	var b strings.Builder
+	for i, backend := range backends {
+		if backend == nil {
+			continue
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (%s:%d)\n", backend.Name, backend.ForwardProtocol, backend.ForwardPort)
+
+		servers := statsPerBackend[backend.ID]
+		if len(servers) == 0 {
+			b.WriteString("  no health checks yet\n")
+			continue
+		}
+		sort.SliceStable(servers, func(i, j int) bool {
+			return servers[i].IP < servers[j].IP
+		})
+
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		for _, s := range servers {
+			since := ""
+			if s.ServerStateChangedAt != nil {
+				since = "since " + s.ServerStateChangedAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", s.IP, s.ServerState, s.LastHealthCheckStatus, since)
+		}
+		_ = w.Flush()
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,66 @@
+package scaleway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type PrivateNetwork struct {
+	sdk.PrivateNetwork `json:"private_network"`
+	VPC                sdk.VPC `json:"vpc"`
+	// Attached are the resources attached to the Private Network, found during the discovery.
+	Attached []resource.NetworkAttachment `json:"attached"`
+}
+
+func (pn PrivateNetwork) Metadata() resource.Metadata {
+	subnets := make([]string, 0, len(pn.Subnets))
+	for _, subnet := range pn.Subnets {
+		if subnet == nil {
+			continue
+		}
+		subnets = append(subnets, subnet.Subnet.String())
+	}
+	description := strings.Join(subnets, ", ")
+
+	return resource.Metadata{
+		ID:          pn.PrivateNetwork.ID,
+		Name:        pn.PrivateNetwork.Name,
+		ProjectID:   pn.PrivateNetwork.ProjectID,
+		Description: &description,
+		CreatedAt:   pn.PrivateNetwork.CreatedAt,
+		Tags:        pn.PrivateNetwork.Tags,
+		Type:        resource.TypePrivateNetwork,
+		Locality:    resource.Region(pn.PrivateNetwork.Region),
+	}
+}
+
+func (pn PrivateNetwork) Parent() resource.Resource {
+	return VPC(pn.VPC)
+}
+
+func (pn PrivateNetwork) Attachments() []resource.NetworkAttachment {
+	return pn.Attached
+}
+
+func (pn PrivateNetwork) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (pn PrivateNetwork) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	err := api.DeletePrivateNetwork(&sdk.DeletePrivateNetworkRequest{
+		PrivateNetworkID: pn.PrivateNetwork.ID,
+		Region:           pn.PrivateNetwork.Region,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, pn)
+}
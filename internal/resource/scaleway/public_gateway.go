@@ -0,0 +1,56 @@
+package scaleway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type PublicGateway sdk.Gateway
+
+func (gw PublicGateway) Metadata() resource.Metadata {
+	details := make([]string, 0, 2)
+	if gw.Type != nil {
+		details = append(details, gw.Type.Name)
+	}
+	if gw.IP != nil {
+		details = append(details, gw.IP.Address.String())
+	}
+	description := strings.Join(details, ", ")
+
+	return resource.Metadata{
+		ID:          gw.ID,
+		Name:        gw.Name,
+		ProjectID:   gw.ProjectID,
+		Status:      statusPtr(gw.Status),
+		Description: &description,
+		CreatedAt:   gw.CreatedAt,
+		Tags:        gw.Tags,
+		Type:        resource.TypePublicGateway,
+		Locality:    resource.Zone(gw.Zone),
+	}
+}
+
+func (gw PublicGateway) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (gw PublicGateway) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	err := api.DeleteGateway(&sdk.DeleteGatewayRequest{
+		GatewayID: gw.ID,
+		Zone:      gw.Zone,
+		// the DHCP configurations are only used by the gateway.
+		CleanupDHCP: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, gw)
+}
@@ -26,9 +26,10 @@ func (i RdbInstance) Metadata() resource.Metadata {
 
 func (i RdbInstance) CockpitMetadata() resource.CockpitMetadata {
 	return resource.CockpitMetadata{
-		CanViewLogs:  true,
-		ResourceID:   i.ID,
-		ResourceType: "rdb_instance_postgresql",
+		CanViewLogs:    true,
+		CanViewMetrics: true,
+		ResourceID:     i.ID,
+		ResourceType:   "rdb_instance_postgresql",
 	}
 }
 
@@ -0,0 +1,50 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type Snapshot sdk.Snapshot
+
+func (s Snapshot) Metadata() resource.Metadata {
+	description := fmt.Sprintf("%s, %d GB", s.VolumeType, s.Size/scw.GB)
+	if s.BaseVolume != nil {
+		description += ", from " + s.BaseVolume.Name
+	}
+
+	return resource.Metadata{
+		ID:          s.ID,
+		Name:        s.Name,
+		ProjectID:   s.Project,
+		Status:      statusPtr(s.State),
+		Description: &description,
+		CreatedAt:   s.CreationDate,
+		Tags:        s.Tags,
+		Type:        resource.TypeSnapshot,
+		Locality:    resource.Zone(s.Zone),
+	}
+}
+
+func (s Snapshot) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (s Snapshot) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	err := api.DeleteSnapshot(&sdk.DeleteSnapshotRequest{
+		SnapshotID: s.ID,
+		Zone:       s.Zone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, s)
+}
@@ -0,0 +1,121 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type Volume sdk.Volume
+
+// orphaned is in the description of the volumes and IPs that are not attached to anything,
+// but still billed, so they can be searched.
+const orphaned = "orphaned"
+
+func (v Volume) Metadata() resource.Metadata {
+	description := fmt.Sprintf("%s, %d GB", v.VolumeType, v.Size/scw.GB)
+	if v.Server != nil {
+		description += ", attached to " + v.Server.Name
+	} else {
+		description += ", " + orphaned
+	}
+
+	return resource.Metadata{
+		ID:          v.ID,
+		Name:        v.Name,
+		ProjectID:   v.Project,
+		Status:      statusPtr(v.State),
+		Description: &description,
+		CreatedAt:   v.CreationDate,
+		Tags:        v.Tags,
+		Type:        resource.TypeVolume,
+		Locality:    resource.Zone(v.Zone),
+	}
+}
+
+func (v Volume) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (v Volume) AttachedTo() resource.Resource {
+	if v.Server == nil {
+		return nil
+	}
+	return attachedInstance(v.Server, v.Project, v.Zone)
+}
+
+func (v Volume) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	err := api.DeleteVolume(&sdk.DeleteVolumeRequest{
+		VolumeID: v.ID,
+		Zone:     v.Zone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, v)
+}
+
+func (v Volume) Actions() []resource.Action {
+	actions := []resource.Action{
+		{
+			Name: "Snapshot",
+			Do: func(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+				api := sdk.NewAPI(client)
+				r, err := api.CreateSnapshot(&sdk.CreateSnapshotRequest{
+					Zone:     v.Zone,
+					Name:     fmt.Sprintf("%s-%s", v.Name, time.Now().Format("20060102-150405")),
+					VolumeID: &v.ID,
+					Project:  &v.Project,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+
+				return index.Index(ctx, Snapshot(*r.Snapshot))
+			},
+		},
+	}
+
+	if v.Server != nil {
+		actions = append(actions, resource.Action{
+			Name: "Detach",
+			Do: func(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+				api := sdk.NewAPI(client)
+				_, err := api.DetachVolume(&sdk.DetachVolumeRequest{
+					Zone:     v.Zone,
+					VolumeID: v.ID,
+					// the volumes of the Instance API are the only ones discovered.
+					IsBlockVolume: scw.BoolPtr(false),
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+
+				detached := v
+				detached.Server = nil
+				return index.Index(ctx, detached)
+			},
+		})
+	}
+
+	return actions
+}
+
+// attachedInstance returns the instance a volume or an IP is attached to.
+// Only its summary is known, which is enough to identify it.
+func attachedInstance(server *sdk.ServerSummary, projectID string, zone scw.Zone) Instance {
+	return Instance(sdk.Server{
+		ID:      server.ID,
+		Name:    server.Name,
+		Project: projectID,
+		Zone:    zone,
+	})
+}
@@ -0,0 +1,49 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	sdk "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type VPC sdk.VPC
+
+func (vpc VPC) Metadata() resource.Metadata {
+	description := fmt.Sprintf("%d private networks", vpc.PrivateNetworkCount)
+	if vpc.IsDefault {
+		description = "default, " + description
+	}
+
+	return resource.Metadata{
+		ID:          vpc.ID,
+		Name:        vpc.Name,
+		ProjectID:   vpc.ProjectID,
+		Description: &description,
+		CreatedAt:   vpc.CreatedAt,
+		Tags:        vpc.Tags,
+		Type:        resource.TypeVPC,
+		Locality:    resource.Region(vpc.Region),
+	}
+}
+
+func (vpc VPC) CockpitMetadata() resource.CockpitMetadata {
+	return resource.CockpitMetadata{
+		CanViewLogs: false,
+	}
+}
+
+func (vpc VPC) Delete(ctx context.Context, index resource.Indexer, client *scw.Client) error {
+	api := sdk.NewAPI(client)
+	err := api.DeleteVPC(&sdk.DeleteVPCRequest{
+		VpcID:  vpc.ID,
+		Region: vpc.Region,
+	})
+	if err != nil {
+		return err
+	}
+
+	return index.Deindex(ctx, vpc)
+}
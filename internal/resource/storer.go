@@ -19,6 +19,12 @@ type Storer interface {
 	// ListAllResources returns all resources.
 	ListAllResources(ctx context.Context) ([]Resource, error)
 
+	// ListProjectResources returns all resources of a project, including the project itself.
+	ListProjectResources(ctx context.Context, projectID string) ([]Resource, error)
+
+	// ListRevisions returns the revisions of a resource, from the most recent to the oldest.
+	ListRevisions(ctx context.Context, r Resource) ([]Revision, error)
+
 	// DeleteResource deletes a resource.
 	DeleteResource(ctx context.Context, r Resource) error
 }
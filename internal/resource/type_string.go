@@ -21,12 +21,24 @@ func _() {
 	_ = x[TypeInstance-10]
 	_ = x[TypeJobDefinition-11]
 	_ = x[TypeJobRun-12]
-	_ = x[NumberOfResourceTypes-13]
+	_ = x[TypeLoadBalancer-13]
+	_ = x[TypeLBFrontend-14]
+	_ = x[TypeLBBackend-15]
+	_ = x[TypeBucket-16]
+	_ = x[TypeVolume-17]
+	_ = x[TypeSnapshot-18]
+	_ = x[TypeFlexibleIP-19]
+	_ = x[TypeVPC-20]
+	_ = x[TypePrivateNetwork-21]
+	_ = x[TypePublicGateway-22]
+	_ = x[TypeKapsulePool-23]
+	_ = x[TypeKapsuleNode-24]
+	_ = x[NumberOfResourceTypes-25]
 }
 
-const _Type_name = "ProjectIAM ApplicationCockpitFunction NamespaceFunctionContainer NamespaceContainerRegistry NamespaceRDB InstanceKapsule ClusterInstanceJob DefinitionJob RunNumberOfResourceTypes"
+const _Type_name = "ProjectIAM ApplicationCockpitFunction NamespaceFunctionContainer NamespaceContainerRegistry NamespaceRDB InstanceKapsule ClusterInstanceJob DefinitionJob RunLoad BalancerLB FrontendLB BackendBucketVolumeSnapshotFlexible IPVPCPrivate NetworkPublic GatewayKapsule PoolKapsule NodeNumberOfResourceTypes"
 
-var _Type_index = [...]uint8{0, 7, 22, 29, 47, 55, 74, 83, 101, 113, 128, 136, 150, 157, 178}
+var _Type_index = [...]uint16{0, 7, 22, 29, 47, 55, 74, 83, 101, 113, 128, 136, 150, 157, 170, 181, 191, 197, 203, 211, 222, 225, 240, 254, 266, 278, 299}
 
 func (i Type) String() string {
 	if i < 0 || i >= Type(len(_Type_index)-1) {
@@ -18,5 +18,17 @@ const (
 	TypeInstance
 	TypeJobDefinition // Job Definition
 	TypeJobRun        // Job Run
+	TypeLoadBalancer  // Load Balancer
+	TypeLBFrontend    // LB Frontend
+	TypeLBBackend     // LB Backend
+	TypeBucket
+	TypeVolume
+	TypeSnapshot
+	TypeFlexibleIP     // Flexible IP
+	TypeVPC            // VPC
+	TypePrivateNetwork // Private Network
+	TypePublicGateway  // Public Gateway
+	TypeKapsulePool    // Kapsule Pool
+	TypeKapsuleNode    // Kapsule Node
 	NumberOfResourceTypes
 )
@@ -15,4 +15,14 @@ type Resource struct {
 	Type        int64
 	Locality    interface{}
 	JsonData    interface{}
+	Status      interface{}
+}
+
+type ResourceRevision struct {
+	Revision   int64
+	ResourceID interface{}
+	Type       int64
+	Status     interface{}
+	JsonData   interface{}
+	CreatedAt  string
 }
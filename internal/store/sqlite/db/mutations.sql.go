@@ -12,11 +12,18 @@ import (
 const deleteResource = `-- name: DeleteResource :one
 DELETE FROM resources
 WHERE id = ?
+    AND type = ?
 RETURNING resources.id
 `
 
-func (q *Queries) DeleteResource(ctx context.Context, id interface{}) (interface{}, error) {
-	row := q.db.QueryRowContext(ctx, deleteResource, id)
+type DeleteResourceParams struct {
+	ID   interface{}
+	Type int64
+}
+
+func (q *Queries) DeleteResource(ctx context.Context, arg DeleteResourceParams) (interface{}, error) {
+	row := q.db.QueryRowContext(ctx, deleteResource, arg.ID, arg.Type)
+	var id interface{}
 	err := row.Scan(&id)
 	return id, err
 }
@@ -30,6 +37,7 @@ INSERT INTO resources (
         tags,
         type,
         locality,
+        status,
         json_data
     )
 VALUES (
@@ -40,7 +48,8 @@ VALUES (
         ?5,
         ?6,
         ?7,
-        json(?8)
+        ?8,
+        json(?9)
     ) ON CONFLICT (id, type) DO
 UPDATE
 SET name = excluded.name,
@@ -49,6 +58,7 @@ SET name = excluded.name,
     tags = excluded.tags,
     type = excluded.type,
     locality = excluded.locality,
+    status = excluded.status,
     json_data = excluded.json_data
 WHERE resources.id = excluded.id
 RETURNING resources.type,
@@ -63,6 +73,7 @@ type UpsertResourceParams struct {
 	Tags        interface{}
 	Type        int64
 	Locality    interface{}
+	Status      interface{}
 	Data        interface{}
 }
 
@@ -80,6 +91,7 @@ func (q *Queries) UpsertResource(ctx context.Context, arg UpsertResourceParams)
 		arg.Tags,
 		arg.Type,
 		arg.Locality,
+		arg.Status,
 		arg.Data,
 	)
 	var i UpsertResourceRow
@@ -62,3 +62,90 @@ func (q *Queries) ListAllResources(ctx context.Context) ([]ListAllResourcesRow,
 	}
 	return items, nil
 }
+
+const listProjectResources = `-- name: ListProjectResources :many
+SELECT resources.type,
+    resources.json_data AS data
+FROM resources
+WHERE project_id = ?
+ORDER BY resources.name ASC
+`
+
+type ListProjectResourcesRow struct {
+	Type int64
+	Data interface{}
+}
+
+func (q *Queries) ListProjectResources(ctx context.Context, projectID interface{}) ([]ListProjectResourcesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProjectResources, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProjectResourcesRow
+	for rows.Next() {
+		var i ListProjectResourcesRow
+		if err := rows.Scan(&i.Type, &i.Data); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResourceRevisions = `-- name: ListResourceRevisions :many
+SELECT resource_revisions.revision,
+    resource_revisions.status,
+    resource_revisions.json_data AS data,
+    resource_revisions.created_at
+FROM resource_revisions
+WHERE resource_id = ?
+    AND type = ?
+ORDER BY resource_revisions.revision DESC
+`
+
+type ListResourceRevisionsParams struct {
+	ResourceID interface{}
+	Type       int64
+}
+
+type ListResourceRevisionsRow struct {
+	Revision  int64
+	Status    interface{}
+	Data      interface{}
+	CreatedAt string
+}
+
+func (q *Queries) ListResourceRevisions(ctx context.Context, arg ListResourceRevisionsParams) ([]ListResourceRevisionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResourceRevisions, arg.ResourceID, arg.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListResourceRevisionsRow
+	for rows.Next() {
+		var i ListResourceRevisionsRow
+		if err := rows.Scan(
+			&i.Revision,
+			&i.Status,
+			&i.Data,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -54,6 +54,30 @@ func (s ScalewayResourceUnmarshal) UnmarshalResource(resourceType resource.Type,
 		return fromString[scaleway.JobDefinition](resourceData)
 	case resource.TypeJobRun:
 		return fromString[scaleway.JobRun](resourceData)
+	case resource.TypeLoadBalancer:
+		return fromString[scaleway.LoadBalancer](resourceData)
+	case resource.TypeLBFrontend:
+		return fromString[scaleway.LBFrontend](resourceData)
+	case resource.TypeLBBackend:
+		return fromString[scaleway.LBBackend](resourceData)
+	case resource.TypeBucket:
+		return fromString[scaleway.Bucket](resourceData)
+	case resource.TypeVolume:
+		return fromString[scaleway.Volume](resourceData)
+	case resource.TypeSnapshot:
+		return fromString[scaleway.Snapshot](resourceData)
+	case resource.TypeFlexibleIP:
+		return fromString[scaleway.FlexibleIP](resourceData)
+	case resource.TypeVPC:
+		return fromString[scaleway.VPC](resourceData)
+	case resource.TypePrivateNetwork:
+		return fromString[scaleway.PrivateNetwork](resourceData)
+	case resource.TypePublicGateway:
+		return fromString[scaleway.PublicGateway](resourceData)
+	case resource.TypeKapsulePool:
+		return fromString[scaleway.KapsulePool](resourceData)
+	case resource.TypeKapsuleNode:
+		return fromString[scaleway.KapsuleNode](resourceData)
 	default:
 		return nil, fmt.Errorf("store: unknown resource type %s", resourceType)
 	}
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrSchemaTooRecent is returned when the database was migrated by a more recent version of scwtui.
+var ErrSchemaTooRecent = errors.New("store: database schema is more recent than this version of scwtui")
+
+// Migrations are named after their version, e.g. 0001_create_resources.sql.
+// Once released, a migration must never be modified: add a new one instead.
+//
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+type migration struct {
+	version int
+	name    string
+	ddl     string
+}
+
+func loadMigrations() ([]migration, error) {
+	entries, err := migrationsFS.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0, len(entries))
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			return nil, fmt.Errorf("store: invalid migration name %q", entry.Name())
+		}
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("store: invalid migration name %q: %w", entry.Name(), err)
+		}
+
+		ddl, err := migrationsFS.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, migration{
+			version: version,
+			name:    entry.Name(),
+			ddl:     string(ddl),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+
+	for i, m := range migrations {
+		if m.version != i+1 {
+			return nil, fmt.Errorf("store: migration %q should have version %d", m.name, i+1)
+		}
+	}
+
+	return migrations, nil
+}
+
+// migrate applies the migrations that were not applied yet.
+// The version of the schema is kept in the user_version pragma of the database.
+func migrate(ctx context.Context, sqlDB *sql.DB) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	var current int
+	if err := sqlDB.QueryRowContext(ctx, "PRAGMA user_version").Scan(&current); err != nil {
+		return fmt.Errorf("store: failed to read schema version: %w", err)
+	}
+
+	if current > len(migrations) {
+		return fmt.Errorf("%w: got version %d, expected at most %d", ErrSchemaTooRecent, current, len(migrations))
+	}
+
+	for _, m := range migrations[current:] {
+		if err := applyMigration(ctx, sqlDB, m); err != nil {
+			return fmt.Errorf("store: failed to apply migration %q: %w", m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, sqlDB *sql.DB, m migration) error {
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, m.ddl); err != nil {
+		return err
+	}
+
+	// PRAGMA statements do not support placeholders.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", m.version)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
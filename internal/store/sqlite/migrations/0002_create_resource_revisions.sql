@@ -0,0 +1,51 @@
+-- Keep the history of the resources
+ALTER TABLE resources ADD COLUMN status;
+
+CREATE TABLE resource_revisions (
+    revision INTEGER PRIMARY KEY AUTOINCREMENT,
+    resource_id char(36) NOT NULL,
+    type int NOT NULL,
+    status,
+    -- JSON data of the resource at this revision
+    json_data NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE INDEX resource_revisions_resource ON resource_revisions (resource_id, type);
+
+CREATE TRIGGER resources_insert_revision
+AFTER INSERT ON resources
+BEGIN
+    INSERT INTO resource_revisions (resource_id, type, status, json_data)
+    VALUES (new.id, new.type, new.status, new.json_data);
+END;
+
+-- Upserts that do not change the resource are not recorded.
+-- Only the last 100 revisions of a resource are kept.
+CREATE TRIGGER resources_update_revision
+AFTER UPDATE OF json_data ON resources
+WHEN old.json_data IS NOT new.json_data
+BEGIN
+    INSERT INTO resource_revisions (resource_id, type, status, json_data)
+    VALUES (new.id, new.type, new.status, new.json_data);
+
+    DELETE FROM resource_revisions
+    WHERE resource_id = new.id
+        AND type = new.type
+        AND revision NOT IN (
+            SELECT revision
+            FROM resource_revisions
+            WHERE resource_id = new.id
+                AND type = new.type
+            ORDER BY revision DESC
+            LIMIT 100
+        );
+END;
+
+CREATE TRIGGER resources_delete_revisions
+AFTER DELETE ON resources
+BEGIN
+    DELETE FROM resource_revisions
+    WHERE resource_id = old.id
+        AND type = old.type;
+END;
@@ -20,6 +20,12 @@ func (s *Store) Store(ctx context.Context, r resource.Resource) error {
 	}
 
 	m := r.Metadata()
+
+	var status interface{}
+	if m.Status != nil {
+		status = string(*m.Status)
+	}
+
 	_, err = s.queries.UpsertResource(ctx, db.UpsertResourceParams{
 		ID:          m.ID,
 		Name:        m.Name,
@@ -28,6 +34,7 @@ func (s *Store) Store(ctx context.Context, r resource.Resource) error {
 		Tags:        strings.Join(m.Tags, ","),
 		Type:        int64(m.Type),
 		Locality:    m.Locality,
+		Status:      status,
 		Data:        string(jsonData),
 	})
 	if err != nil {
@@ -39,7 +46,11 @@ func (s *Store) Store(ctx context.Context, r resource.Resource) error {
 
 // DeleteResource implements resource.Storer.
 func (s *Store) DeleteResource(ctx context.Context, r resource.Resource) error {
-	_, err := s.queries.DeleteResource(ctx, r.Metadata().ID)
+	m := r.Metadata()
+	_, err := s.queries.DeleteResource(ctx, db.DeleteResourceParams{
+		ID:   m.ID,
+		Type: int64(m.Type),
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return resource.ErrResourceNotFound
@@ -7,6 +7,7 @@ INSERT INTO resources (
         tags,
         type,
         locality,
+        status,
         json_data
     )
 VALUES (
@@ -17,6 +18,7 @@ VALUES (
         :tags,
         :type,
         :locality,
+        :status,
         json(:data)
     ) ON CONFLICT (id, type) DO
 UPDATE
@@ -26,6 +28,7 @@ SET name = excluded.name,
     tags = excluded.tags,
     type = excluded.type,
     locality = excluded.locality,
+    status = excluded.status,
     json_data = excluded.json_data
 WHERE resources.id = excluded.id
 RETURNING resources.type,
@@ -35,4 +38,5 @@ RETURNING resources.type,
 -- name: DeleteResource :one
 DELETE FROM resources
 WHERE id = ?
+    AND type = ?
 RETURNING resources.id;
\ No newline at end of file
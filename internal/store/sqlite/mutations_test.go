@@ -2,6 +2,7 @@ package sqlite_test
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 	"testing"
 
@@ -50,6 +51,7 @@ func TestStore_Store(t *testing.T) {
 			typ         int
 			locality    string
 			data        string
+			status      sql.NullString
 		)
 
 		err = store.DB.QueryRowContext(ctx, "SELECT * FROM resources WHERE id = ?", r.Metadata().ID).Scan(
@@ -61,6 +63,7 @@ func TestStore_Store(t *testing.T) {
 			&typ,
 			&locality,
 			&data,
+			&status,
 		)
 		require.NoError(t, err)
 
@@ -73,6 +76,7 @@ func TestStore_Store(t *testing.T) {
 		assert.Equal(t, meta.Tags, strings.Split(tags, ",")) // tags are stored as a comma separated string
 		assert.Equal(t, meta.Type, resource.Type(typ))
 		assert.Equal(t, meta.Locality, resource.Region(scw.RegionFrPar))
+		assert.False(t, status.Valid)
 	})
 
 	t.Run("store resource that already exists", func(t *testing.T) {
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/store/sqlite/db"
 )
 
 // GetResource implements resource.Storer.
@@ -42,3 +44,59 @@ func (s *Store) ListAllResources(ctx context.Context) ([]resource.Resource, erro
 
 	return resources, nil
 }
+
+// ListProjectResources implements resource.Storer.
+func (s *Store) ListProjectResources(ctx context.Context, projectID string) ([]resource.Resource, error) {
+	rows, err := s.queries.ListProjectResources(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to list resources of project with id %s: %w", projectID, err)
+	}
+
+	resources := make([]resource.Resource, 0, len(rows))
+
+	for _, row := range rows {
+		r, err := s.unmarshaller.UnmarshalResource(resource.Type(row.Type), row.Data.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// ListRevisions implements resource.Storer.
+func (s *Store) ListRevisions(ctx context.Context, r resource.Resource) ([]resource.Revision, error) {
+	m := r.Metadata()
+	rows, err := s.queries.ListResourceRevisions(ctx, db.ListResourceRevisionsParams{
+		ResourceID: m.ID,
+		Type:       int64(m.Type),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("store: failed to list revisions of resource with id %s: %w", m.ID, err)
+	}
+
+	revisions := make([]resource.Revision, 0, len(rows))
+
+	for _, row := range rows {
+		createdAt, err := time.Parse(time.RFC3339Nano, row.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("store: failed to parse revision date: %w", err)
+		}
+
+		revision := resource.Revision{
+			Revision:  row.Revision,
+			JSON:      row.Data.(string),
+			CreatedAt: createdAt,
+		}
+
+		if status, ok := row.Status.(string); ok {
+			revision.Status = (*resource.Status)(&status)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
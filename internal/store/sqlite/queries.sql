@@ -10,3 +10,22 @@ SELECT resources.type,
     resources.json_data AS data
 FROM resources
 ORDER BY resources.name ASC;
+
+
+-- name: ListProjectResources :many
+SELECT resources.type,
+    resources.json_data AS data
+FROM resources
+WHERE project_id = ?
+ORDER BY resources.name ASC;
+
+
+-- name: ListResourceRevisions :many
+SELECT resource_revisions.revision,
+    resource_revisions.status,
+    resource_revisions.json_data AS data,
+    resource_revisions.created_at
+FROM resource_revisions
+WHERE resource_id = ?
+    AND type = ?
+ORDER BY resource_revisions.revision DESC;
@@ -2,13 +2,19 @@ package sqlite_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/cyclimse/scwtui/internal/resource"
 	"github.com/cyclimse/scwtui/internal/resource/scaleway"
 	"github.com/cyclimse/scwtui/internal/store/sqlite"
 	cockpit_sdk "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
 	fnc_sdk "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	instance_sdk "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	lb_sdk "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	registry_sdk "github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	vpc_sdk "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,6 +66,61 @@ func TestStore(t *testing.T) {
 				Status:    fnc_sdk.NamespaceStatusReady,
 			},
 		},
+		{
+			name: "scaleway load balancer frontend",
+			resource: scaleway.LBFrontend{
+				ID:          "frontend-id",
+				Name:        "frontend-name",
+				InboundPort: 443,
+				LB: &lb_sdk.LB{
+					ID:        "lb-id",
+					Name:      "lb-name",
+					ProjectID: "project-id",
+					Status:    lb_sdk.LBStatusReady,
+					Zone:      scw.ZoneFrPar1,
+					// the enums without a value are read back as unknown.
+					SslCompatibilityLevel: lb_sdk.SSLCompatibilityLevelSslCompatibilityLevelUnknown,
+				},
+			},
+		},
+		{
+			name: "scaleway bucket",
+			resource: scaleway.Bucket{
+				Name:      "bucket-name",
+				ProjectID: "project-id",
+				Region:    scw.RegionFrPar,
+				Endpoint:  "https://s3.fr-par.scw.cloud",
+			},
+		},
+		{
+			name: "scaleway volume",
+			resource: scaleway.Volume{
+				ID:         "volume-id",
+				Name:       "volume-name",
+				Size:       20 * scw.GB,
+				VolumeType: instance_sdk.VolumeVolumeTypeBSSD,
+				Project:    "project-id",
+				Server:     &instance_sdk.ServerSummary{ID: "server-id", Name: "server-name"},
+				State:      instance_sdk.VolumeStateAvailable,
+				Zone:       scw.ZoneFrPar1,
+			},
+		},
+		{
+			name: "scaleway private network",
+			resource: scaleway.PrivateNetwork{
+				PrivateNetwork: vpc_sdk.PrivateNetwork{
+					ID:        "pn-id",
+					Name:      "pn-name",
+					ProjectID: "project-id",
+					Region:    scw.RegionFrPar,
+					VpcID:     "vpc-id",
+				},
+				VPC: vpc_sdk.VPC{ID: "vpc-id", Name: "vpc-name", ProjectID: "project-id", Region: scw.RegionFrPar},
+				Attached: []resource.NetworkAttachment{
+					{ID: "server-id", Name: "server-name", Type: resource.TypeInstance, Locality: "fr-par-1", Address: "172.16.0.2"},
+				},
+			},
+		},
 		{
 			name: "scaleway cockpit",
 			resource: scaleway.Cockpit{
@@ -90,3 +151,75 @@ func TestStore(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_ListRevisions(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.NewStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	ns := scaleway.RegistryNamespace{
+		ID:        "namespace-id",
+		Name:      "namespace-name",
+		ProjectID: "project-id",
+		Region:    scw.RegionFrPar,
+		Status:    registry_sdk.NamespaceStatusReady,
+	}
+
+	require.NoError(t, store.Store(ctx, ns))
+	// storing the same resource again should not create a new revision
+	require.NoError(t, store.Store(ctx, ns))
+
+	ns.Status = registry_sdk.NamespaceStatusError
+	require.NoError(t, store.Store(ctx, ns))
+
+	revisions, err := store.ListRevisions(ctx, ns)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	assert.Greater(t, revisions[0].Revision, revisions[1].Revision)
+	assert.Equal(t, resource.StatusError, *revisions[0].Status)
+	assert.Equal(t, resource.StatusReady, *revisions[1].Status)
+	assert.JSONEq(t, revisions[0].JSON, mustMarshal(t, ns))
+	assert.WithinDuration(t, time.Now(), revisions[0].CreatedAt, time.Minute)
+
+	t.Run("revisions are removed with the resource", func(t *testing.T) {
+		require.NoError(t, store.DeleteResource(ctx, ns))
+
+		revisions, err := store.ListRevisions(ctx, ns)
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+}
+
+func TestStore_ListProjectResources(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.NewStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	inProject := scaleway.RegistryNamespace{ID: "namespace-id", Name: "namespace", ProjectID: "project-id", Region: scw.RegionFrPar}
+	cockpit := scaleway.Cockpit{ProjectID: "project-id"}
+	otherProject := scaleway.RegistryNamespace{ID: "other-id", Name: "other", ProjectID: "other-project-id", Region: scw.RegionFrPar}
+
+	for _, r := range []resource.Resource{inProject, cockpit, otherProject} {
+		require.NoError(t, store.Store(ctx, r))
+	}
+
+	resources, err := store.ListProjectResources(ctx, "project-id")
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(resources))
+	for _, r := range resources {
+		ids = append(ids, r.Metadata().ID)
+	}
+	assert.ElementsMatch(t, []string{"namespace-id", "project-id"}, ids)
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
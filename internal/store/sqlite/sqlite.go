@@ -3,29 +3,41 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	_ "embed"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/cyclimse/scwtui/internal/store/sqlite/db"
 	//nolint:blankimport // sqlite3 driver
 	_ "github.com/mattn/go-sqlite3"
 )
 
-//go:embed schema.sql
-var ddl string
-
-// NewStore creates a new store.
+// NewStore creates a new in-memory store.
 // The store is used to save the resources in a database, to avoid querying the API every time.
 // It also allows doing some analysis on the resources, like finding dangling resources.
 func NewStore(ctx context.Context, shard string) (*Store, error) {
-	sqlDB, err := sql.Open("sqlite3", fmt.Sprintf("file:%s:?mode=memory&cache=shared", shard))
+	return newStore(ctx, fmt.Sprintf("file:%s:?mode=memory&cache=shared", shard))
+}
+
+// NewPersistentStore creates a new store backed by the database file at path.
+// The resources are kept between sessions. The schema is migrated to the latest version if needed.
+func NewPersistentStore(ctx context.Context, path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("store: failed to create database directory: %w", err)
+	}
+
+	return newStore(ctx, fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL", path))
+}
+
+func newStore(ctx context.Context, dsn string) (*Store, error) {
+	sqlDB, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("store: failed to open database: %w", err)
 	}
 
-	_, err = sqlDB.ExecContext(ctx, ddl)
-	if err != nil {
-		return nil, fmt.Errorf("store: failed to create schema: %w", err)
+	if err := migrate(ctx, sqlDB); err != nil {
+		sqlDB.Close()
+		return nil, err
 	}
 
 	queries := db.New(sqlDB)
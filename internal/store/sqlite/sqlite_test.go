@@ -0,0 +1,57 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	"github.com/cyclimse/scwtui/internal/store/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPersistentStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("resources are kept between sessions", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "profile", "scwtui.db")
+		project := scaleway.Project{
+			ID:   "project-id",
+			Name: "project-name",
+		}
+
+		store, err := sqlite.NewPersistentStore(ctx, path)
+		require.NoError(t, err)
+		require.NoError(t, store.Store(ctx, project))
+		require.NoError(t, store.Close())
+
+		// reopening the store should not apply the migrations again
+		store, err = sqlite.NewPersistentStore(ctx, path)
+		require.NoError(t, err)
+		defer store.Close()
+
+		retrieved, err := store.GetResource(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, project, retrieved)
+	})
+
+	t.Run("database from a more recent version", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "scwtui.db")
+
+		store, err := sqlite.NewPersistentStore(ctx, path)
+		require.NoError(t, err)
+		_, err = store.DB.ExecContext(ctx, "PRAGMA user_version = 1000")
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		_, err = sqlite.NewPersistentStore(ctx, path)
+		require.ErrorIs(t, err, sqlite.ErrSchemaTooRecent)
+	})
+}
@@ -0,0 +1,214 @@
+package testhelpers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeS3 is a stand-in for the few operations of the S3 API used to browse the buckets.
+// The buckets are addressed with the path style.
+type FakeS3 struct {
+	mutex sync.Mutex
+
+	// Buckets are the objects of each bucket, by key.
+	Buckets map[string]map[string]FakeObject
+	// CreatedAt is the creation date of all the buckets.
+	CreatedAt time.Time
+	// AccessKeys are the access keys of the requests, in order.
+	AccessKeys []string
+}
+
+type FakeObject struct {
+	Body         string
+	LastModified time.Time
+}
+
+// Objects returns the keys of the objects of a bucket.
+func (f *FakeS3) Objects(bucket string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	keys := make([]string, 0, len(f.Buckets[bucket]))
+	for key := range f.Buckets[bucket] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HasBucket returns true if the bucket exists.
+func (f *FakeS3) HasBucket(bucket string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	_, ok := f.Buckets[bucket]
+	return ok
+}
+
+type fakeS3Bucket struct {
+	Name         string    `xml:"Name"`
+	CreationDate time.Time `xml:"CreationDate"`
+}
+
+type fakeS3ListBucketsResult struct {
+	XMLName xml.Name       `xml:"ListAllMyBucketsResult"`
+	Buckets []fakeS3Bucket `xml:"Buckets>Bucket"`
+}
+
+type fakeS3Content struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+	Size         int64     `xml:"Size"`
+}
+
+type fakeS3Prefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type fakeS3ListObjectsResult struct {
+	XMLName        xml.Name        `xml:"ListBucketResult"`
+	Name           string          `xml:"Name"`
+	Prefix         string          `xml:"Prefix"`
+	Delimiter      string          `xml:"Delimiter"`
+	KeyCount       int             `xml:"KeyCount"`
+	MaxKeys        int             `xml:"MaxKeys"`
+	IsTruncated    bool            `xml:"IsTruncated"`
+	Contents       []fakeS3Content `xml:"Contents"`
+	CommonPrefixes []fakeS3Prefix  `xml:"CommonPrefixes"`
+}
+
+type fakeS3Error struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+func (f *FakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	// the credential is "<access key>/<date>/<region>/s3/aws4_request".
+	auth := r.Header.Get("Authorization")
+	if _, credential, ok := strings.Cut(auth, "Credential="); ok {
+		accessKey, _, _ := strings.Cut(credential, "/")
+		f.AccessKeys = append(f.AccessKeys, accessKey)
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	switch {
+	case bucket == "" && r.Method == http.MethodGet:
+		f.listBuckets(w)
+	case key == "" && r.Method == http.MethodGet:
+		f.listObjects(w, r, bucket)
+	case key == "" && r.Method == http.MethodDelete:
+		f.deleteBucket(w, bucket)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		f.getObject(w, r, bucket, key)
+	case r.Method == http.MethodDelete:
+		if objects, ok := f.Buckets[bucket]; ok {
+			delete(objects, key)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *FakeS3) listBuckets(w http.ResponseWriter) {
+	var result fakeS3ListBucketsResult
+	for name := range f.Buckets {
+		result.Buckets = append(result.Buckets, fakeS3Bucket{Name: name, CreationDate: f.CreatedAt})
+	}
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].Name < result.Buckets[j].Name
+	})
+	writeFakeS3XML(w, result)
+}
+
+func (f *FakeS3) listObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	objects, ok := f.Buckets[bucket]
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	query := r.URL.Query()
+	prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+
+	result := fakeS3ListObjectsResult{Name: bucket, Prefix: prefix, Delimiter: delimiter, MaxKeys: 1000}
+	seen := make(map[string]bool)
+	for key, obj := range objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(key, prefix)
+		if i := strings.Index(rest, delimiter); delimiter != "" && i >= 0 {
+			common := prefix + rest[:i+len(delimiter)]
+			if !seen[common] {
+				seen[common] = true
+				result.CommonPrefixes = append(result.CommonPrefixes, fakeS3Prefix{Prefix: common})
+			}
+			continue
+		}
+		result.Contents = append(result.Contents, fakeS3Content{
+			Key:          key,
+			LastModified: obj.LastModified,
+			ETag:         `"etag"`,
+			Size:         int64(len(obj.Body)),
+		})
+	}
+	sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+	sort.Slice(result.CommonPrefixes, func(i, j int) bool { return result.CommonPrefixes[i].Prefix < result.CommonPrefixes[j].Prefix })
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+
+	writeFakeS3XML(w, result)
+}
+
+func (f *FakeS3) deleteBucket(w http.ResponseWriter, bucket string) {
+	objects, ok := f.Buckets[bucket]
+	switch {
+	case !ok:
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+	case len(objects) > 0:
+		writeFakeS3Error(w, http.StatusConflict, "BucketNotEmpty")
+	default:
+		delete(f.Buckets, bucket)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *FakeS3) getObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	obj, ok := f.Buckets[bucket][key]
+	if !ok {
+		writeFakeS3Error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(obj.Body)))
+	w.Header().Set("Last-Modified", obj.LastModified.UTC().Format(http.TimeFormat))
+	w.Header().Set("ETag", `"etag"`)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write([]byte(obj.Body))
+	}
+}
+
+func writeFakeS3XML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func writeFakeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(fakeS3Error{Code: code, Message: fmt.Sprintf("%s (%d)", code, status)})
+}
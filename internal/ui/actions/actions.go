@@ -1,6 +1,9 @@
 package actions
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,8 +13,61 @@ import (
 )
 
 func Actions(state ui.ApplicationState, r resource.Actionable, width, height int) Model {
-	actions := r.Actions()
+	m := newModel(state, "Actions for "+r.Metadata().Type.String(), r.Actions(), width, height)
+	m.resource = r
+	return m
+}
+
+// Shared shows the actions that all the resources have in common.
+// Picking one of them sends a SharedActionMsg instead of executing it.
+func Shared(state ui.ApplicationState, resources []resource.Resource, width, height int) Model {
+	m := newModel(state, fmt.Sprintf("Actions for %d resources", len(resources)), SharedActions(resources), width, height)
+	m.shared = true
+	return m
+}
+
+// SharedActions returns the actions of the first resource that are also available on all the others.
+// The actions are matched by name.
+func SharedActions(resources []resource.Resource) []resource.Action {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, r := range resources {
+		actionable, ok := r.(resource.Actionable)
+		if !ok {
+			return nil
+		}
+		seen := make(map[string]struct{})
+		for _, action := range actionable.Actions() {
+			if action.Report != nil {
+				continue
+			}
+			if _, ok := seen[action.Name]; ok {
+				continue
+			}
+			seen[action.Name] = struct{}{}
+			counts[action.Name]++
+		}
+	}
+
+	var shared []resource.Action
+	for _, action := range resources[0].(resource.Actionable).Actions() {
+		if action.Report == nil && counts[action.Name] == len(resources) {
+			shared = append(shared, action)
+			counts[action.Name] = 0
+		}
+	}
+	return shared
+}
 
+// SharedActionMsg is sent when a shared action is picked.
+type SharedActionMsg struct {
+	Name string
+}
+
+func newModel(state ui.ApplicationState, title string, actions []resource.Action, width, height int) Model {
 	items := make([]list.Item, 0, len(actions))
 	for _, action := range actions {
 		items = append(items, Action(action))
@@ -20,7 +76,7 @@ func Actions(state ui.ApplicationState, r resource.Actionable, width, height int
 	listHeight := 10
 
 	l := list.New(items, actionDelegate{}, state.Styles.ModalWidth, listHeight)
-	l.Title = "Actions for " + r.Metadata().Type.String()
+	l.Title = title
 	l.Styles.Title = state.Styles.Title
 
 	// disable help, pagination, filter and status bar
@@ -30,12 +86,11 @@ func Actions(state ui.ApplicationState, r resource.Actionable, width, height int
 	l.SetShowStatusBar(false)
 
 	return Model{
-		list:     l,
-		state:    state,
-		resource: r,
-		actions:  actions,
-		width:    width,
-		height:   height,
+		list:    l,
+		state:   state,
+		actions: actions,
+		width:   width,
+		height:  height,
 	}
 }
 
@@ -48,12 +103,20 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// only esc, handled by the root, goes back from the result.
+		if m.result != nil {
+			return m, nil
+		}
 		switch {
 		case key.Matches(msg, m.state.Keys.ActionsKeyMap.Do):
 			action, ok := m.list.SelectedItem().(Action)
 			if !ok {
 				return m, nil
 			}
+			if m.shared {
+				return m, func() tea.Msg { return SharedActionMsg{Name: action.Name} }
+			}
+			m.running = action.Name
 			return m, action.Command(m.state)
 		}
 	}
@@ -63,7 +126,21 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
+// SetResult shows the report or the error of the action, until the user goes back to the table.
+func (m *Model) SetResult(msg ActionResultMsg) {
+	m.result = &msg
+}
+
+// HasResult returns true if the action only reports an error or a text.
+func HasResult(msg ActionResultMsg) bool {
+	return msg.Err != nil || msg.Report != ""
+}
+
 func (m Model) View() string {
+	if m.result != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.viewResult())
+	}
+
 	content := m.state.Styles.Modal.Render(m.list.View())
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
 }
@@ -75,4 +152,23 @@ type Model struct {
 	actions  []resource.Action
 	width    int
 	height   int
+
+	// shared is true when the actions are picked for several resources.
+	shared bool
+
+	// running is the name of the last action, and result its outcome once it is shown.
+	running string
+	result  *ActionResultMsg
+}
+
+func (m Model) viewResult() string {
+	var b strings.Builder
+	b.WriteString(m.state.Styles.Title.Render(m.running))
+	b.WriteString("\n\n")
+	if m.result.Err != nil {
+		b.WriteString(m.state.Styles.Error.Render(m.result.Err.Error()))
+	} else {
+		b.WriteString(m.result.Report)
+	}
+	return m.state.Styles.Modal.Render(b.String())
 }
@@ -0,0 +1,76 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+type actionableResource struct {
+	testhelpers.MockResource
+	names []string
+}
+
+func (r *actionableResource) Actions() []resource.Action {
+	actions := make([]resource.Action, 0, len(r.names))
+	for _, name := range r.names {
+		actions = append(actions, resource.Action{
+			Name: name,
+			Do:   func(context.Context, resource.Indexer, *scw.Client) error { return nil },
+		})
+	}
+	return actions
+}
+
+func actionNames(actions []resource.Action) []string {
+	names := make([]string, 0, len(actions))
+	for _, action := range actions {
+		names = append(names, action.Name)
+	}
+	return names
+}
+
+func TestSharedActions(t *testing.T) {
+	startStop := &actionableResource{names: []string{"Start", "Stop"}}
+	stopStart := &actionableResource{names: []string{"Stop", "Start", "Restart"}}
+	stop := &actionableResource{names: []string{"Stop"}}
+
+	tests := []struct {
+		name      string
+		resources []resource.Resource
+		want      []string
+	}{
+		{"no resources", nil, []string{}},
+		{"single resource", []resource.Resource{startStop}, []string{"Start", "Stop"}},
+		{"common actions in the order of the first resource", []resource.Resource{startStop, stopStart}, []string{"Start", "Stop"}},
+		{"only shared actions", []resource.Resource{stopStart, stop}, []string{"Stop"}},
+		{"resource without actions", []resource.Resource{startStop, &testhelpers.MockResource{}}, []string{}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, actionNames(SharedActions(test.resources)))
+		})
+	}
+}
+
+func TestSharedActions_SkipsReports(t *testing.T) {
+	report := func(context.Context, *scw.Client) (string, error) { return "healthy", nil }
+	r := &reportingResource{actionableResource: actionableResource{names: []string{"Stop"}}, report: report}
+
+	assert.Equal(t, []string{"Stop"}, actionNames(SharedActions([]resource.Resource{r, r})))
+}
+
+type reportingResource struct {
+	actionableResource
+	report func(context.Context, *scw.Client) (string, error)
+}
+
+func (r *reportingResource) Actions() []resource.Action {
+	return append(r.actionableResource.Actions(), resource.Action{Name: "Show health", Report: r.report})
+}
@@ -27,11 +27,17 @@ func (a Action) FilterValue() string {
 
 type ActionResultMsg struct {
 	Err error
+	// Report is the text returned by the actions that show something.
+	Report string
 }
 
 func (a Action) Command(state ui.ApplicationState) tea.Cmd {
 	return func() tea.Msg {
-		return ActionResultMsg{Err: a.Do(context.Background(), resource.NewIndex(state.Store, state.Search), state.ScwClient)}
+		if a.Report != nil {
+			report, err := a.Report(context.Background(), state.ScwClient)
+			return ActionResultMsg{Err: err, Report: report}
+		}
+		return ActionResultMsg{Err: a.Do(context.Background(), state.Index, state.ScwClient)}
 	}
 }
 
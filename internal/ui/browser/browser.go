@@ -0,0 +1,454 @@
+package browser
+
+// A component to browse the objects of a bucket, one prefix at a time.
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/objectstorage"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/mattn/go-runewidth"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// Bucket is a resource whose objects can be browsed.
+type Bucket interface {
+	resource.Resource
+	Client(client *scw.Client) (*objectstorage.Client, error)
+}
+
+type Status int
+
+const (
+	// StatusLoading indicates that the objects are being listed.
+	StatusLoading Status = iota
+	// StatusLoaded indicates that the objects have been listed.
+	StatusLoaded
+)
+
+const (
+	dateFormat = "2006-01-02 15:04:05"
+
+	// requestTimeout is the maximum duration of a listing, a deletion or the signature of a URL.
+	requestTimeout = 30 * time.Second
+	// presignExpiry is how long the presigned URLs are valid.
+	presignExpiry = time.Hour
+
+	// sizeWidth and dateWidth are the widths of the columns after the names.
+	sizeWidth = 10
+	dateWidth = len(dateFormat)
+)
+
+func Browser(state ui.ApplicationState, bucket Bucket, width, height int) Model {
+	di := textinput.New()
+	di.Prompt = "Download to: "
+
+	m := Model{
+		state:         state,
+		bucket:        bucket,
+		name:          bucket.Metadata().Name,
+		spinner:       spinner.New(spinner.WithSpinner(spinner.Line)),
+		status:        StatusLoading,
+		downloadInput: di,
+		width:         width,
+		height:        height,
+	}
+
+	client, err := bucket.Client(state.ScwClient)
+	if err != nil {
+		m.status = StatusLoaded
+		m.errorMsg = fmt.Sprintf("Error connecting to Object Storage: %s", err)
+		return m
+	}
+	m.client = client
+
+	return m
+}
+
+type ObjectsMsg struct {
+	Err     error
+	Objects []objectstorage.Object
+	Prefix  string
+	// generation is used to ignore the objects of a previous prefix.
+	generation int
+}
+
+func (m Model) listObjects() tea.Cmd {
+	client, bucket, prefix, generation := m.client, m.name, m.prefix, m.generation
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		objects, err := client.ListObjects(ctx, bucket, prefix)
+		return ObjectsMsg{Err: err, Objects: objects, Prefix: prefix, generation: generation}
+	}
+}
+
+// DownloadedMsg is sent once an object was written to a file.
+type DownloadedMsg struct {
+	Err  error
+	Key  string
+	Path string
+	Size int64
+}
+
+func (m Model) download(key, path string) tea.Cmd {
+	client, bucket := m.client, m.name
+	return func() tea.Msg {
+		// the objects can be large, so there is no timeout.
+		n, err := client.Download(context.Background(), bucket, key, path)
+		return DownloadedMsg{Err: err, Key: key, Path: path, Size: n}
+	}
+}
+
+type DeletedMsg struct {
+	Err error
+	Key string
+}
+
+func (m Model) deleteObject(key string) tea.Cmd {
+	client, bucket := m.client, m.name
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		return DeletedMsg{Err: client.DeleteObject(ctx, bucket, key), Key: key}
+	}
+}
+
+type PresignedMsg struct {
+	Err error
+	Key string
+	URL string
+	// Copied is true if the URL was copied to the clipboard.
+	Copied bool
+}
+
+func (m Model) presign(key string) tea.Cmd {
+	client, bucket := m.client, m.name
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		u, err := client.PresignedURL(ctx, bucket, key, presignExpiry)
+		if err != nil {
+			return PresignedMsg{Err: err, Key: key}
+		}
+		// there may be no clipboard, such as over SSH, so the URL is shown anyway.
+		copied := clipboard.WriteAll(u) == nil
+		return PresignedMsg{Key: key, URL: u, Copied: copied}
+	}
+}
+
+// Init initializes the browser component.
+func (m Model) Init() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	return tea.Batch(m.spinner.Tick, m.listObjects())
+}
+
+// Editing returns true if the user is typing the path of a download.
+func (m Model) Editing() bool {
+	return m.downloadInput.Focused()
+}
+
+// open lists the objects under another prefix.
+func (m *Model) open(prefix string) tea.Cmd {
+	m.prefix = prefix
+	return m.reload()
+}
+
+func (m *Model) reload() tea.Cmd {
+	m.generation++
+	m.status = StatusLoading
+	m.errorMsg = ""
+	m.pendingDelete = ""
+	return tea.Batch(m.spinner.Tick, m.listObjects())
+}
+
+// selected returns the object under the cursor.
+func (m Model) selected() (objectstorage.Object, bool) {
+	if m.status != StatusLoaded || m.cursor >= len(m.objects) {
+		return objectstorage.Object{}, false
+	}
+	return m.objects[m.cursor], true
+}
+
+func (m Model) updateDownloadInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.downloadInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		path := strings.TrimSpace(m.downloadInput.Value())
+		obj, ok := m.selected()
+		if path == "" || !ok {
+			return m, nil
+		}
+		m.downloadInput.Blur()
+		m.message = "Downloading " + obj.Key + "..."
+		return m, m.download(obj.Key, path)
+	}
+
+	m.downloadInput, cmd = m.downloadInput.Update(msg)
+	return m, cmd
+}
+
+//nolint:funlen,gocognit // a case for each key and message.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.client == nil {
+			return m, nil
+		}
+		if m.downloadInput.Focused() {
+			return m.updateDownloadInput(msg)
+		}
+
+		keys := m.state.Keys.BrowserKeyMap
+
+		// the deletion is confirmed by pressing the key again, any other key cancels it.
+		if m.pendingDelete != "" {
+			pending := m.pendingDelete
+			m.pendingDelete = ""
+			if key.Matches(msg, keys.DeleteObject) {
+				m.message = "Deleting " + pending + "..."
+				return m, m.deleteObject(pending)
+			}
+			m.message = ""
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, keys.PreviousObject):
+			m.cursor = max(m.cursor-1, 0)
+		case key.Matches(msg, keys.NextObject):
+			m.cursor = max(min(m.cursor+1, len(m.objects)-1), 0)
+		case key.Matches(msg, keys.OpenPrefix):
+			if obj, ok := m.selected(); ok && obj.IsPrefix {
+				return m, m.open(obj.Key)
+			}
+		case key.Matches(msg, keys.ParentPrefix):
+			if m.prefix != "" {
+				return m, m.open(parentPrefix(m.prefix))
+			}
+		case key.Matches(msg, keys.Reload):
+			return m, m.reload()
+		case key.Matches(msg, keys.Download):
+			if obj, ok := m.selected(); ok && !obj.IsPrefix {
+				m.downloadInput.SetValue(path.Base(obj.Key))
+				m.downloadInput.CursorEnd()
+				m.message = ""
+				return m, m.downloadInput.Focus()
+			}
+		case key.Matches(msg, keys.DeleteObject):
+			if obj, ok := m.selected(); ok && !obj.IsPrefix {
+				m.pendingDelete = obj.Key
+				m.message = fmt.Sprintf("Press %s again to delete %s, any other key to cancel", keys.DeleteObject.Help().Key, obj.Key)
+			}
+		case key.Matches(msg, keys.PresignURL):
+			if obj, ok := m.selected(); ok && !obj.IsPrefix {
+				m.message = "Signing a URL for " + obj.Key + "..."
+				return m, m.presign(obj.Key)
+			}
+		}
+		return m, nil
+	case ObjectsMsg:
+		if msg.generation != m.generation {
+			return m, nil
+		}
+		m.status = StatusLoaded
+		if msg.Err != nil {
+			m.errorMsg = fmt.Sprintf("Error listing objects: %s", msg.Err)
+			m.objects = nil
+			return m, nil
+		}
+		m.objects = msg.Objects
+		m.cursor = min(m.cursor, max(len(m.objects)-1, 0))
+		return m, nil
+	case DownloadedMsg:
+		if msg.Err != nil {
+			m.message = ""
+			m.errorMsg = fmt.Sprintf("Error downloading %s: %s", msg.Key, msg.Err)
+			return m, nil
+		}
+		m.message = fmt.Sprintf("Downloaded %s to %s (%s)", msg.Key, msg.Path, formatSize(msg.Size))
+		return m, nil
+	case DeletedMsg:
+		if msg.Err != nil {
+			m.message = ""
+			m.errorMsg = fmt.Sprintf("Error deleting %s: %s", msg.Key, msg.Err)
+			return m, nil
+		}
+		cmd = m.reload()
+		m.message = "Deleted " + msg.Key
+		return m, cmd
+	case PresignedMsg:
+		if msg.Err != nil {
+			m.message = ""
+			m.errorMsg = fmt.Sprintf("Error signing a URL for %s: %s", msg.Key, msg.Err)
+			return m, nil
+		}
+		m.message = fmt.Sprintf("URL of %s, valid for %s", msg.Key, presignExpiry)
+		if msg.Copied {
+			m.message += " (copied to the clipboard)"
+		}
+		m.message += ":\n" + msg.URL
+		return m, nil
+	case spinner.TickMsg:
+		if m.status != StatusLoading {
+			return m, nil
+		}
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// parentPrefix returns the prefix above, such as "a/" for "a/b/".
+func parentPrefix(prefix string) string {
+	trimmed := strings.TrimSuffix(prefix, objectstorage.Delimiter)
+	i := strings.LastIndex(trimmed, objectstorage.Delimiter)
+	if i < 0 {
+		return ""
+	}
+	return trimmed[:i+len(objectstorage.Delimiter)]
+}
+
+// formatSize formats a number of bytes, such as "512 B" or "1.5 MiB".
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func (m Model) View() string {
+	var body string
+
+	switch {
+	case m.status == StatusLoading:
+		body = lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.spinner.View())
+	case len(m.objects) == 0:
+		body = lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, "No objects")
+	default:
+		body = lipgloss.NewStyle().Height(m.height).MaxHeight(m.height).Render(m.viewObjects())
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.viewHeader(),
+		m.state.Styles.BaseBorder.Width(m.width).Render(body),
+	)
+}
+
+func (m Model) viewHeader() string {
+	header := m.state.Styles.Title.Render("Objects of bucket " + m.name)
+	header += m.state.Styles.Title.Copy().Bold(false).Render("(/" + m.prefix + ")")
+	if m.downloadInput.Focused() {
+		header += "\n" + m.downloadInput.View()
+	} else if m.message != "" {
+		header += "\n" + m.state.Styles.Title.Copy().Bold(false).Render(m.message)
+	}
+	if m.errorMsg != "" {
+		header += "\n" + m.state.Styles.Error.Render(m.errorMsg)
+	}
+	return header
+}
+
+// viewObjects renders the objects around the cursor.
+func (m Model) viewObjects() string {
+	start := 0
+	if m.cursor >= m.height {
+		start = m.cursor - m.height + 1
+	}
+	end := min(start+m.height, len(m.objects))
+
+	// the marker, the name and the two other columns, separated by spaces.
+	nameWidth := max(m.width-2-sizeWidth-dateWidth-2, 1)
+
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		obj := m.objects[i]
+
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+		}
+
+		size, modified := "", ""
+		if !obj.IsPrefix {
+			size = formatSize(obj.Size)
+			modified = obj.LastModified.Local().Format(dateFormat)
+		}
+
+		name := runewidth.FillRight(runewidth.Truncate(obj.Name(m.prefix), nameWidth, "…"), nameWidth)
+		line := fmt.Sprintf("%s%s %*s %s", marker, name, sizeWidth, size, modified)
+		if i == m.cursor {
+			line = m.state.Styles.LogSelected.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) SetDimensions(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Model is the model for the browser component.
+type Model struct {
+	// errorMsg is the error message to display.
+	errorMsg string
+	// message is the outcome of the last operation, or the deletion waiting for a confirmation.
+	message string
+	// state is the context.
+	state ui.ApplicationState
+
+	bucket Bucket
+	name   string
+	client *objectstorage.Client
+
+	// status is the status of the listing.
+	status  Status
+	spinner spinner.Model
+
+	// prefix is the "directory" being browsed, and objects the objects and prefixes under it.
+	prefix  string
+	objects []objectstorage.Object
+	cursor  int
+	// generation is incremented each time the objects are listed again, to ignore the previous listings.
+	generation int
+
+	// downloadInput is used to type the path of a download.
+	downloadInput textinput.Model
+	// pendingDelete is the key of the object to delete, once confirmed.
+	pendingDelete string
+
+	width  int
+	height int
+}
@@ -0,0 +1,153 @@
+package browser
+
+import (
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBrowser(t *testing.T, s3 *testhelpers.FakeS3) Model {
+	t.Helper()
+
+	server := httptest.NewServer(s3)
+	t.Cleanup(server.Close)
+
+	client, err := scw.NewClient(scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"))
+	require.NoError(t, err)
+
+	state := ui.ApplicationState{
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ScwClient: client,
+		Keys:      ui.DefaultKeyMap(),
+		Styles:    ui.DefaultStyles(),
+	}
+	bucket := scaleway.Bucket{Name: "assets", ProjectID: "project-id", Region: scw.RegionFrPar, Endpoint: server.URL}
+
+	m := Browser(state, bucket, 80, 10)
+	m, _ = m.Update(m.listObjects()())
+	require.Equal(t, StatusLoaded, m.status)
+	return m
+}
+
+func keyMsg(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModel_Browse(t *testing.T) {
+	modified := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	s3 := &testhelpers.FakeS3{Buckets: map[string]map[string]testhelpers.FakeObject{
+		"assets": {
+			"index.html":   {Body: "<html></html>", LastModified: modified},
+			"img/logo.png": {Body: "png", LastModified: modified},
+		},
+	}}
+	m := newTestBrowser(t, s3)
+
+	view := m.View()
+	assert.Contains(t, view, "img/")
+	assert.Contains(t, view, "index.html")
+	assert.Contains(t, view, "13 B")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd, "the prefix should be opened")
+	m, _ = m.Update(m.listObjects()())
+	assert.Equal(t, "img/", m.prefix)
+	require.Len(t, m.objects, 1)
+	assert.Contains(t, m.View(), "logo.png")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m, _ = m.Update(m.listObjects()())
+	assert.Equal(t, "", m.prefix)
+	assert.Len(t, m.objects, 2)
+
+	t.Run("objects of a previous prefix are ignored", func(t *testing.T) {
+		stale := m.listObjects()()
+		m, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m, _ = m.Update(stale)
+		assert.Equal(t, StatusLoading, m.status)
+	})
+}
+
+func TestModel_Operations(t *testing.T) {
+	s3 := &testhelpers.FakeS3{Buckets: map[string]map[string]testhelpers.FakeObject{
+		"assets": {
+			"index.html": {Body: "<html></html>", LastModified: time.Now()},
+			"robots.txt": {Body: "User-agent: *", LastModified: time.Now()},
+		},
+	}}
+	m := newTestBrowser(t, s3)
+
+	t.Run("download", func(t *testing.T) {
+		m, _ := m.Update(keyMsg("d"))
+		require.True(t, m.Editing())
+		assert.Equal(t, "index.html", m.downloadInput.Value())
+
+		path := filepath.Join(t.TempDir(), "index.html")
+		m.downloadInput.SetValue(path)
+		m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		assert.False(t, m.Editing())
+
+		m, _ = m.Update(cmd())
+		assert.Contains(t, m.message, "Downloaded index.html")
+
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "<html></html>", string(b))
+	})
+
+	t.Run("presigned url", func(t *testing.T) {
+		m, cmd := m.Update(keyMsg("u"))
+		require.NotNil(t, cmd)
+
+		m, _ = m.Update(cmd())
+		assert.Empty(t, m.errorMsg)
+		assert.Contains(t, m.message, "X-Amz-Signature=")
+	})
+
+	t.Run("delete is confirmed", func(t *testing.T) {
+		m, cmd := m.Update(keyMsg("x"))
+		assert.Nil(t, cmd)
+		assert.Equal(t, "index.html", m.pendingDelete)
+
+		// any other key cancels the deletion.
+		m, _ = m.Update(keyMsg("j"))
+		assert.Empty(t, m.pendingDelete)
+		assert.Equal(t, 0, m.cursor, "the key should only cancel the deletion")
+
+		m, _ = m.Update(keyMsg("x"))
+		m, cmd = m.Update(keyMsg("x"))
+		require.NotNil(t, cmd)
+
+		m, cmd = m.Update(cmd())
+		require.NotNil(t, cmd, "the objects should be listed again")
+		assert.Equal(t, []string{"robots.txt"}, s3.Objects("assets"))
+
+		m, _ = m.Update(m.listObjects()())
+		assert.Len(t, m.objects, 1)
+		assert.Contains(t, m.message, "Deleted index.html")
+	})
+}
+
+func TestParentPrefix(t *testing.T) {
+	assert.Equal(t, "", parentPrefix("img/"))
+	assert.Equal(t, "img/", parentPrefix("img/old/"))
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KiB", formatSize(1536))
+	assert.Equal(t, "2.0 GiB", formatSize(2<<30))
+}
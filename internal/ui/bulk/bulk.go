@@ -0,0 +1,381 @@
+package bulk
+
+// A component to run an operation on several resources at once.
+// The targets are listed in a single confirmation, then the progress of each resource is shown.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/ui"
+)
+
+const (
+	// The magic word to confirm deletion.
+	magicWord = "DELETE"
+	// The prompt to confirm deletion.
+	deletePrompt = "Type DELETE to confirm"
+	// The height taken by the title, the text and the prompt of the modal.
+	modalChromeHeight = 14
+	// The minimum height of the list of resources.
+	minListHeight = 3
+)
+
+// Delete deletes all the resources.
+func Delete(state ui.ApplicationState, resources []resource.Resource, width, height int) Model {
+	tasks := make([]Task, 0, len(resources))
+	for _, r := range resources {
+		r := r
+		tasks = append(tasks, Task{
+			Resource: r,
+			Do: func(ctx context.Context) error {
+				return r.Delete(ctx, state.Index, state.ScwClient)
+			},
+		})
+	}
+
+	m := newModel(state, tasks, width, height)
+	m.title = "Confirm deletion"
+	m.text = fmt.Sprintf("Will delete the following %d resources. This action cannot be undone.", len(tasks))
+	m.runningTitle = fmt.Sprintf("Deleting %d resources", len(tasks))
+	m.magicWord = magicWord
+	m.textInput.Placeholder = deletePrompt
+	m.textInput.Focus()
+	return m
+}
+
+// Action runs the action with the given name on all the resources.
+// The resources without this action are skipped.
+func Action(state ui.ApplicationState, resources []resource.Resource, name string, width, height int) Model {
+	tasks := make([]Task, 0, len(resources))
+	for _, r := range resources {
+		actionable, ok := r.(resource.Actionable)
+		if !ok {
+			continue
+		}
+		for _, action := range actionable.Actions() {
+			if action.Name != name {
+				continue
+			}
+			action := action
+			tasks = append(tasks, Task{
+				Resource: r,
+				Do: func(ctx context.Context) error {
+					return action.Do(ctx, state.Index, state.ScwClient)
+				},
+			})
+			break
+		}
+	}
+
+	m := newModel(state, tasks, width, height)
+	m.title = "Confirm " + name
+	m.text = fmt.Sprintf("Will run %s on the following %d resources.", name, len(tasks))
+	m.runningTitle = fmt.Sprintf("Running %s on %d resources", name, len(tasks))
+	return m
+}
+
+// DeleteProject deletes a project and all of its resources.
+// The resources are deleted in the order of resource.PlanProjectDeletion.
+func DeleteProject(state ui.ApplicationState, project resource.Resource, width, height int) Model {
+	m := newModel(state, nil, width, height)
+	m.project = project
+	m.title = "Delete project and all its resources"
+	m.text = "Loading the resources of the project..."
+	m.runningTitle = "Deleting project " + project.Metadata().Name
+	m.magicWord = magicWord
+	m.textInput.Placeholder = deletePrompt
+	m.textInput.Focus()
+	return m
+}
+
+type planMsg struct {
+	projectID string
+	plan      resource.DeletionPlan
+	err       error
+}
+
+func loadPlan(state ui.ApplicationState, project resource.Resource) tea.Cmd {
+	return func() tea.Msg {
+		projectID := project.Metadata().ID
+		resources, err := state.Store.ListProjectResources(context.Background(), projectID)
+		if err != nil {
+			return planMsg{projectID: projectID, err: err}
+		}
+		return planMsg{projectID: projectID, plan: resource.PlanProjectDeletion(resources)}
+	}
+}
+
+func (m *Model) setPlan(plan resource.DeletionPlan) {
+	state := m.state
+	m.tasks = make([]Task, 0, len(plan))
+	for _, step := range plan {
+		r := step.Resource
+		m.tasks = append(m.tasks, Task{
+			Resource: r,
+			Do: func(ctx context.Context) error {
+				return r.Delete(ctx, state.Index, state.ScwClient)
+			},
+			DependsOn: step.DependsOn,
+		})
+	}
+	m.states = make([]taskState, len(m.tasks))
+	m.errs = make([]error, len(m.tasks))
+
+	m.text = fmt.Sprintf(
+		"Will delete project %s and its %d resources, from top to bottom. "+
+			"If a deletion fails, the resources that depend on it are skipped. This action cannot be undone.",
+		m.project.Metadata().Name, len(m.tasks)-1,
+	)
+	m.SetDimensions(m.width, m.height)
+}
+
+func newModel(state ui.ApplicationState, tasks []Task, width, height int) Model {
+	m := Model{
+		state:     state,
+		tasks:     tasks,
+		states:    make([]taskState, len(tasks)),
+		errs:      make([]error, len(tasks)),
+		textInput: textinput.New(),
+		viewport:  viewport.New(0, 0),
+	}
+	m.viewport.KeyMap = viewport.KeyMap{}
+	m.SetDimensions(width, height)
+	return m
+}
+
+// Init initializes the bulk component.
+func (m Model) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	if m.project != nil {
+		cmds = append(cmds, loadPlan(m.state, m.project))
+	}
+	if m.magicWord != "" {
+		cmds = append(cmds, textinput.Blink)
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.state.Keys.BulkKeyMap.ScrollUp):
+			m.viewport.LineUp(1)
+			return m, nil
+		case key.Matches(msg, m.state.Keys.BulkKeyMap.ScrollDown):
+			m.viewport.LineDown(1)
+			return m, nil
+		case key.Matches(msg, m.state.Keys.BulkKeyMap.Run):
+			if m.started || len(m.tasks) == 0 || (m.magicWord != "" && m.textInput.Value() != m.magicWord) {
+				return m, nil
+			}
+			return m, m.start()
+		}
+	case planMsg:
+		if m.project == nil || msg.projectID != m.project.Metadata().ID {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.errorMsg = fmt.Sprintf("Error listing the resources of the project: %s", msg.err)
+			return m, nil
+		}
+		m.setPlan(msg.plan)
+		return m, nil
+	case ProgressMsg:
+		// ignore the progress of a previous operation.
+		if msg.events != m.events {
+			return m, nil
+		}
+		if msg.finished {
+			m.finished = true
+			m.refreshContent()
+			return m, nil
+		}
+		m.states[msg.event.index] = msg.event.state
+		m.errs[msg.event.index] = msg.event.err
+		m.refreshContent()
+		return m, waitForEvent(m.events)
+	}
+
+	if !m.started {
+		m.textInput, cmd = m.textInput.Update(msg)
+	}
+
+	return m, cmd
+}
+
+func (m *Model) start() tea.Cmd {
+	m.started = true
+	m.textInput.Blur()
+	m.events = run(context.Background(), m.tasks, Concurrency)
+	m.refreshContent()
+	return waitForEvent(m.events)
+}
+
+// Finished returns true once all the tasks are done.
+func (m Model) Finished() bool {
+	return m.finished
+}
+
+// Failed returns the resources for which the operation failed.
+func (m Model) Failed() []resource.Resource {
+	var failed []resource.Resource
+	for i, s := range m.states {
+		if s == taskFailed {
+			failed = append(failed, m.tasks[i].Resource)
+		}
+	}
+	return failed
+}
+
+func (m Model) describeResource(r resource.Resource) string {
+	metadata := r.Metadata()
+	text := strings.ToLower(metadata.Type.String()) + " " + metadata.Name
+	if metadata.Type != resource.TypeProject {
+		text += " in project " + m.state.ProjectIDsToNames[metadata.ProjectID]
+	}
+	return text
+}
+
+func (m *Model) refreshContent() {
+	lines := make([]string, 0, len(m.tasks))
+	for i, task := range m.tasks {
+		if !m.started {
+			bullet := "- "
+			if m.project != nil {
+				bullet = fmt.Sprintf("%d. ", i+1)
+			}
+			lines = append(lines, bullet+m.describeResource(task.Resource))
+			continue
+		}
+
+		var prefix string
+		switch m.states[i] {
+		case taskPending:
+			prefix = "  "
+		case taskRunning:
+			prefix = "⏳"
+		case taskSucceeded:
+			prefix = "✅"
+		case taskFailed:
+			prefix = "❌"
+		case taskSkipped:
+			prefix = "⏭️"
+		}
+		line := prefix + " " + m.describeResource(task.Resource)
+		if m.states[i] == taskSkipped {
+			line += " (skipped)"
+		}
+		lines = append(lines, line)
+
+		if m.errs[i] != nil {
+			errorStyle := m.state.Styles.Error.Width(m.viewport.Width)
+			lines = append(lines, errorStyle.Render(m.errs[i].Error()))
+		}
+	}
+	m.viewport.SetContent(lipgloss.NewStyle().Width(m.viewport.Width).Render(strings.Join(lines, "\n")))
+}
+
+func (m Model) summary() string {
+	var done, failed, skipped int
+	for _, s := range m.states {
+		switch s {
+		case taskSucceeded:
+			done++
+		case taskFailed:
+			done++
+			failed++
+		case taskSkipped:
+			done++
+			skipped++
+		case taskPending, taskRunning:
+		}
+	}
+
+	text := fmt.Sprintf("%d/%d done", done, len(m.tasks))
+	if failed > 0 {
+		text += fmt.Sprintf(", %d failed", failed)
+	}
+	if skipped > 0 {
+		text += fmt.Sprintf(", %d skipped", skipped)
+	}
+	if m.finished {
+		text += ". Press esc to go back."
+	}
+	return text
+}
+
+func (m Model) viewTitle(title string) string {
+	modalStyle := m.state.Styles.Modal
+	return lipgloss.PlaceHorizontal(modalStyle.GetWidth()-modalStyle.GetHorizontalFrameSize(), lipgloss.Center, m.state.Styles.Title.Render(title))
+}
+
+func (m Model) View() string {
+	var strs []string
+	if m.errorMsg != "" {
+		strs = []string{m.viewTitle(m.title), m.state.Styles.Error.Render(m.errorMsg)}
+	} else if !m.started {
+		strs = []string{m.viewTitle(m.title), m.text, "", m.viewport.View()}
+		if m.magicWord != "" {
+			strs = append(strs, "", m.textInput.View())
+		}
+	} else {
+		strs = []string{m.viewTitle(m.runningTitle), m.viewport.View(), "", m.summary()}
+	}
+
+	content := m.state.Styles.Modal.Render(lipgloss.JoinVertical(lipgloss.Left, strs...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+func (m *Model) SetDimensions(width, height int) {
+	m.width = width
+	m.height = height
+
+	modalStyle := m.state.Styles.Modal
+	m.viewport.Width = modalStyle.GetWidth() - modalStyle.GetHorizontalFrameSize()
+	m.viewport.Height = min(len(m.tasks), max(height-modalChromeHeight, minListHeight))
+	m.textInput.Width = m.viewport.Width
+	m.refreshContent()
+}
+
+// Model is the model for the bulk component.
+type Model struct {
+	state ui.ApplicationState
+
+	// title, text and runningTitle describe the operation.
+	title        string
+	text         string
+	runningTitle string
+
+	// project is set when deleting a project and all of its resources.
+	project resource.Resource
+	// errorMsg is shown instead of the operation, if not empty.
+	errorMsg string
+
+	// magicWord must be typed to confirm the operation, if not empty.
+	magicWord string
+	textInput textinput.Model
+
+	tasks  []Task
+	states []taskState
+	errs   []error
+
+	// events are the progress of the tasks, once started.
+	events   <-chan event
+	started  bool
+	finished bool
+
+	viewport viewport.Model
+	width    int
+	height   int
+}
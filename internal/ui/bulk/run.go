@@ -0,0 +1,110 @@
+package bulk
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+// Concurrency is the maximum number of tasks running at the same time.
+const Concurrency = 4
+
+// Task is an operation on a single resource.
+type Task struct {
+	Resource resource.Resource
+	Do       func(ctx context.Context) error
+
+	// DependsOn are the indexes of the tasks that must succeed before this one.
+	// If one of them fails, the task is skipped.
+	DependsOn []int
+}
+
+type taskState int
+
+const (
+	taskPending taskState = iota
+	taskRunning
+	taskSucceeded
+	taskFailed
+	taskSkipped
+)
+
+type event struct {
+	index int
+	state taskState
+	err   error
+}
+
+// run executes the tasks, with at most concurrency tasks at the same time.
+// A task starts once its dependencies succeeded. A failed task only prevents
+// the tasks that depend on it from running.
+// The returned channel is closed once all the tasks are done.
+func run(ctx context.Context, tasks []Task, concurrency int) <-chan event {
+	// each task sends at most two events, so the workers never block on the UI.
+	events := make(chan event, 2*len(tasks))
+
+	go func() {
+		defer close(events)
+
+		// done[i] is closed once the state of the task i is final.
+		done := make([]chan struct{}, len(tasks))
+		states := make([]taskState, len(tasks))
+		for i := range tasks {
+			done[i] = make(chan struct{})
+		}
+
+		slots := make(chan struct{}, concurrency)
+
+		var wg sync.WaitGroup
+		for i, task := range tasks {
+			i, task := i, task
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(done[i])
+
+				for _, dependency := range task.DependsOn {
+					<-done[dependency]
+					if states[dependency] != taskSucceeded {
+						states[i] = taskSkipped
+						events <- event{index: i, state: taskSkipped}
+						return
+					}
+				}
+
+				slots <- struct{}{}
+				defer func() { <-slots }()
+
+				events <- event{index: i, state: taskRunning}
+				if err := task.Do(ctx); err != nil {
+					states[i] = taskFailed
+					events <- event{index: i, state: taskFailed, err: err}
+					return
+				}
+				states[i] = taskSucceeded
+				events <- event{index: i, state: taskSucceeded}
+			}()
+		}
+
+		wg.Wait()
+	}()
+
+	return events
+}
+
+// ProgressMsg reports the progress of a bulk operation.
+type ProgressMsg struct {
+	events <-chan event
+	event  event
+	// finished is true once all the tasks are done.
+	finished bool
+}
+
+func waitForEvent(events <-chan event) tea.Cmd {
+	return func() tea.Msg {
+		e, ok := <-events
+		return ProgressMsg{events: events, event: e, finished: !ok}
+	}
+}
@@ -0,0 +1,90 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	const concurrency = 2
+
+	var running, maxRunning atomic.Int32
+	errFailed := errors.New("failed")
+
+	tasks := make([]Task, 0, 5)
+	for i := 0; i < 5; i++ {
+		i := i
+		tasks = append(tasks, Task{
+			Do: func(context.Context) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+				if i == 1 {
+					return errFailed
+				}
+				return nil
+			},
+		})
+	}
+
+	states := make([]taskState, len(tasks))
+	errs := make([]error, len(tasks))
+	for e := range run(context.Background(), tasks, concurrency) {
+		states[e.index] = e.state
+		errs[e.index] = e.err
+	}
+
+	assert.LessOrEqual(t, maxRunning.Load(), int32(concurrency))
+	assert.Equal(t, []taskState{taskSucceeded, taskFailed, taskSucceeded, taskSucceeded, taskSucceeded}, states)
+	assert.ErrorIs(t, errs[1], errFailed)
+}
+
+func TestRun_DependsOn(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	var mutex sync.Mutex
+	var order []int
+	task := func(i int, err error, dependsOn ...int) Task {
+		return Task{
+			Do: func(context.Context) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				order = append(order, i)
+				return err
+			},
+			DependsOn: dependsOn,
+		}
+	}
+
+	tasks := []Task{
+		task(0, errFailed),
+		task(1, nil),
+		task(2, nil, 0),    // depends on the failed task.
+		task(3, nil, 1),    // depends on an unrelated task.
+		task(4, nil, 2, 3), // depends on a skipped task.
+	}
+
+	states := make([]taskState, len(tasks))
+	for e := range run(context.Background(), tasks, Concurrency) {
+		states[e.index] = e.state
+	}
+
+	assert.Equal(t, []taskState{taskFailed, taskSucceeded, taskSkipped, taskSucceeded, taskSkipped}, states)
+	assert.Less(t, slices.Index(order, 1), slices.Index(order, 3))
+	assert.NotContains(t, order, 2)
+	assert.NotContains(t, order, 4)
+}
@@ -0,0 +1,229 @@
+package feed
+
+// A pane listing the resources that were created, deleted or changed status.
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	// Height is the height of the pane, including its title and border.
+	Height = 10
+
+	// maxChanges is the number of changes kept in the feed.
+	maxChanges = 500
+
+	timeFormat    = "15:04:05"
+	maxNameLength = 32
+)
+
+type ChangeKind int
+
+const (
+	ChangeCreated ChangeKind = iota
+	ChangeDeleted
+	ChangeStatus
+)
+
+// Change is an event of the feed.
+type Change struct {
+	Kind     ChangeKind
+	Metadata resource.Metadata
+
+	// PreviousStatus is the status before the change, for ChangeStatus.
+	PreviousStatus *resource.Status
+
+	Time time.Time
+}
+
+func Feed(state ui.ApplicationState) Model {
+	return Model{
+		state: state,
+	}
+}
+
+type key struct {
+	ID   string
+	Type resource.Type
+}
+
+// Observe compares the resources with the ones of the previous call and records the changes.
+func (m *Model) Observe(resources []resource.Resource) {
+	current := make(map[key]resource.Metadata, len(resources))
+	for _, r := range resources {
+		metadata := r.Metadata()
+		current[key{ID: metadata.ID, Type: metadata.Type}] = metadata
+	}
+
+	// Until the first discovery has finished, resources are only being discovered,
+	// not created.
+	baseline := m.previous == nil || (m.state.Sync != nil && m.state.Sync.LastSynced().IsZero())
+	if !baseline {
+		m.record(changesBetween(m.previous, current, time.Now()))
+	}
+
+	m.previous = current
+}
+
+// Apply records the changes from the events of the index.
+// Only the resources of the events are compared with their previous state.
+func (m *Model) Apply(events []resource.IndexEvent) {
+	if m.previous == nil {
+		m.previous = make(map[key]resource.Metadata)
+	}
+
+	after := make(map[key]resource.Metadata, len(events))
+	before := make(map[key]resource.Metadata, len(events))
+	seen := make(map[key]struct{}, len(events))
+	for _, e := range events {
+		metadata := e.Resource.Metadata()
+		k := key{ID: metadata.ID, Type: metadata.Type}
+
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			if previous, ok := m.previous[k]; ok {
+				before[k] = previous
+			}
+		}
+
+		if e.Kind == resource.IndexEventDelete {
+			delete(after, k)
+			delete(m.previous, k)
+			continue
+		}
+		after[k] = metadata
+		m.previous[k] = metadata
+	}
+
+	// Until the first discovery has finished, resources are only being discovered,
+	// not created.
+	if m.state.Sync != nil && m.state.Sync.LastSynced().IsZero() {
+		return
+	}
+
+	// the resources that were deleted are in before but not in after.
+	m.record(changesBetween(before, after, time.Now()))
+}
+
+func (m *Model) record(changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	// the most recent changes are first.
+	m.changes = append(changes, m.changes...)
+	if len(m.changes) > maxChanges {
+		m.changes = m.changes[:maxChanges]
+	}
+}
+
+func changesBetween(before, after map[key]resource.Metadata, now time.Time) []Change {
+	changes := make([]Change, 0)
+
+	for k, metadata := range after {
+		previous, ok := before[k]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeCreated, Metadata: metadata, Time: now})
+			continue
+		}
+
+		if statusOf(previous) != statusOf(metadata) {
+			changes = append(changes, Change{
+				Kind:           ChangeStatus,
+				Metadata:       metadata,
+				PreviousStatus: previous.Status,
+				Time:           now,
+			})
+		}
+	}
+
+	for k, metadata := range before {
+		if _, ok := after[k]; !ok {
+			changes = append(changes, Change{Kind: ChangeDeleted, Metadata: metadata, Time: now})
+		}
+	}
+
+	return changes
+}
+
+func statusOf(metadata resource.Metadata) resource.Status {
+	if metadata.Status == nil {
+		return resource.StatusUnknown
+	}
+	return *metadata.Status
+}
+
+// Changes returns the recorded changes, from the most recent to the oldest.
+func (m Model) Changes() []Change {
+	return m.changes
+}
+
+func (m Model) View() string {
+	// title and border.
+	lines := Height - 3
+
+	var b strings.Builder
+	for i, c := range m.changes {
+		if i >= lines {
+			break
+		}
+		if i > 0 {
+			b.WriteRune('\n')
+		}
+		b.WriteString(runewidth.Truncate(formatChange(c), m.width, "…"))
+	}
+
+	body := lipgloss.NewStyle().Height(lines).Render(b.String())
+	if len(m.changes) == 0 {
+		body = lipgloss.Place(m.width, lines, lipgloss.Center, lipgloss.Center, "No changes yet")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.state.Styles.Title.Render("Changes"),
+		m.state.Styles.BaseBorder.Width(m.width).Render(body),
+	)
+}
+
+func formatChange(c Change) string {
+	name := runewidth.Truncate(c.Metadata.Name, maxNameLength, "…")
+	subject := fmt.Sprintf("%s %s", strings.ToLower(c.Metadata.Type.String()), name)
+
+	var event string
+	switch c.Kind {
+	case ChangeCreated:
+		event = subject + " created"
+	case ChangeDeleted:
+		event = subject + " deleted"
+	case ChangeStatus:
+		previous := resource.StatusUnknown
+		if c.PreviousStatus != nil {
+			previous = *c.PreviousStatus
+		}
+		event = fmt.Sprintf("%s: %s → %s", subject, previous, statusOf(c.Metadata))
+	}
+
+	return c.Time.Local().Format(timeFormat) + " " + event
+}
+
+func (m *Model) SetWidth(width int) {
+	m.width = width
+}
+
+// Model is the model for the feed component.
+type Model struct {
+	// state of the application
+	state ui.ApplicationState
+	// previous is the last snapshot of the resources
+	previous map[key]resource.Metadata
+	// changes are the recorded changes, from the most recent to the oldest
+	changes []Change
+	width   int
+}
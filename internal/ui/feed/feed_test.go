@@ -0,0 +1,98 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statusPtr(s resource.Status) *resource.Status {
+	return &s
+}
+
+func mockResource(id string, status resource.Status) *testhelpers.MockResource {
+	return &testhelpers.MockResource{
+		MetadataValue: resource.Metadata{
+			ID:     id,
+			Name:   id,
+			Status: statusPtr(status),
+			Type:   resource.TypeContainer,
+		},
+	}
+}
+
+func TestModel_Observe(t *testing.T) {
+	m := Feed(ui.ApplicationState{})
+
+	kept := mockResource("kept", resource.StatusReady)
+	deleted := mockResource("deleted", resource.StatusReady)
+
+	// the first snapshot is the baseline.
+	m.Observe([]resource.Resource{kept, deleted})
+	assert.Empty(t, m.Changes())
+
+	created := mockResource("created", resource.StatusPending)
+	m.Observe([]resource.Resource{mockResource("kept", resource.StatusError), created})
+
+	changes := m.Changes()
+	require.Len(t, changes, 3)
+
+	byID := make(map[string]Change, len(changes))
+	for _, c := range changes {
+		byID[c.Metadata.ID] = c
+	}
+
+	assert.Equal(t, ChangeCreated, byID["created"].Kind)
+	assert.Equal(t, ChangeDeleted, byID["deleted"].Kind)
+	assert.Equal(t, ChangeStatus, byID["kept"].Kind)
+	assert.Equal(t, resource.StatusReady, *byID["kept"].PreviousStatus)
+
+	// nothing changed.
+	m.Observe([]resource.Resource{mockResource("kept", resource.StatusError), created})
+	assert.Len(t, m.Changes(), 3)
+}
+
+func TestFormatChange(t *testing.T) {
+	c := Change{
+		Kind:           ChangeStatus,
+		Metadata:       mockResource("api-prod", resource.StatusError).Metadata(),
+		PreviousStatus: statusPtr(resource.StatusReady),
+		Time:           time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local),
+	}
+
+	assert.Equal(t, "12:00:00 container api-prod: ready → error", formatChange(c))
+}
+
+func TestModel_Apply(t *testing.T) {
+	m := Feed(ui.ApplicationState{})
+
+	kept := mockResource("kept", resource.StatusReady)
+	deleted := mockResource("deleted", resource.StatusReady)
+	m.Observe([]resource.Resource{kept, deleted})
+
+	m.Apply([]resource.IndexEvent{
+		{Kind: resource.IndexEventUpsert, Resource: mockResource("kept", resource.StatusReady)},
+		{Kind: resource.IndexEventUpsert, Resource: mockResource("created", resource.StatusPending)},
+		{Kind: resource.IndexEventDelete, Resource: deleted},
+		{Kind: resource.IndexEventUpsert, Resource: mockResource("short-lived", resource.StatusPending)},
+		{Kind: resource.IndexEventDelete, Resource: mockResource("short-lived", resource.StatusPending)},
+	})
+
+	changes := m.Changes()
+	require.Len(t, changes, 2)
+
+	kinds := map[string]ChangeKind{}
+	for _, c := range changes {
+		kinds[c.Metadata.ID] = c.Kind
+	}
+	assert.Equal(t, map[string]ChangeKind{"created": ChangeCreated, "deleted": ChangeDeleted}, kinds)
+
+	// the snapshot is kept up to date, so polling does not record the changes again.
+	m.Observe([]resource.Resource{kept, mockResource("created", resource.StatusPending)})
+	assert.Len(t, m.Changes(), 2)
+}
@@ -13,6 +13,12 @@ const (
 	ConfirmFocused
 	ActionsFocused
 	JournalFocused
+	DiscoveryFocused
+	HistoryFocused
+	BulkFocused
+	MetricsFocused
+	BrowserFocused
+	TopologyFocused
 	NumViews // The number of views in the app
 )
 
@@ -3,6 +3,7 @@ package header
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	tea "github.com/charmbracelet/bubbletea"
@@ -37,6 +38,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 const (
 	// infoTemplate is the template for the info text.
 	infoTemplate = `Scaleway Profile: %s`
+	// syncedTemplate is the template for the last synced indicator.
+	syncedTemplate = ` | Last synced %s ago`
+	// notSyncedYet is displayed until the first discovery has finished.
+	notSyncedYet = ` | Syncing...`
 
 	// additionalHorizontalPadding is the additional padding to add to the left for the help menu.
 	additionalHorizontalPadding = 2
@@ -48,7 +53,7 @@ func (m Model) View() string {
 		return m.help.View(m.state.Keys.Get(m.focused))
 	}
 
-	info := fmt.Sprintf(infoTemplate, m.state.ScwProfileName)
+	info := fmt.Sprintf(infoTemplate, m.state.ScwProfileName) + m.viewSynced()
 	widthAfterInfo := m.width - lipgloss.Width(info) - additionalHorizontalPadding
 	return baseStyle.Render(
 		lipgloss.JoinHorizontal(0,
@@ -60,6 +65,19 @@ func (m Model) View() string {
 	)
 }
 
+func (m Model) viewSynced() string {
+	if m.state.Sync == nil {
+		return ""
+	}
+
+	lastSynced := m.state.Sync.LastSynced()
+	if lastSynced.IsZero() {
+		return notSyncedYet
+	}
+
+	return fmt.Sprintf(syncedTemplate, time.Since(lastSynced).Truncate(time.Second))
+}
+
 func (m *Model) SetWidth(width int) {
 	m.width = width
 	m.help.Width = int(math.Floor(float64(width) * 0.75))
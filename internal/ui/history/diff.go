@@ -0,0 +1,92 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+type diffLine struct {
+	Op   diffOp
+	Text string
+}
+
+// diffJSON compares two JSON documents line by line, once indented.
+func diffJSON(from, to string) []diffLine {
+	a := indentLines(from)
+	b := indentLines(to)
+
+	lines := make([]diffLine, 0, len(b))
+	for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+		switch op.Tag {
+		case 'e':
+			for _, l := range b[op.J1:op.J2] {
+				lines = append(lines, diffLine{Op: diffEqual, Text: l})
+			}
+		case 'd':
+			for _, l := range a[op.I1:op.I2] {
+				lines = append(lines, diffLine{Op: diffDelete, Text: l})
+			}
+		case 'i':
+			for _, l := range b[op.J1:op.J2] {
+				lines = append(lines, diffLine{Op: diffInsert, Text: l})
+			}
+		case 'r':
+			for _, l := range a[op.I1:op.I2] {
+				lines = append(lines, diffLine{Op: diffDelete, Text: l})
+			}
+			for _, l := range b[op.J1:op.J2] {
+				lines = append(lines, diffLine{Op: diffInsert, Text: l})
+			}
+		}
+	}
+
+	return lines
+}
+
+func indentLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(s), "", "  "); err != nil {
+		// not valid JSON, compare it as is.
+		return strings.Split(s, "\n")
+	}
+
+	return strings.Split(b.String(), "\n")
+}
+
+//nolint:gochecknoglobals
+var (
+	insertStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	deleteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+func renderDiff(lines []diffLine) string {
+	var b strings.Builder
+	for _, l := range lines {
+		switch l.Op {
+		case diffEqual:
+			b.WriteString("  " + l.Text)
+		case diffDelete:
+			b.WriteString(deleteStyle.Render("- " + l.Text))
+		case diffInsert:
+			b.WriteString(insertStyle.Render("+ " + l.Text))
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffJSON(t *testing.T) {
+	from := `{"name":"api","status":"ready","port":8080}`
+	to := `{"name":"api","status":"error","port":8080}`
+
+	lines := diffJSON(from, to)
+
+	assert.Equal(t, []diffLine{
+		{Op: diffEqual, Text: "{"},
+		{Op: diffEqual, Text: `  "name": "api",`},
+		{Op: diffDelete, Text: `  "status": "ready",`},
+		{Op: diffInsert, Text: `  "status": "error",`},
+		{Op: diffEqual, Text: `  "port": 8080`},
+		{Op: diffEqual, Text: "}"},
+	}, lines)
+}
+
+func TestDiffJSON_FirstRevision(t *testing.T) {
+	lines := diffJSON("", `{"name":"api"}`)
+
+	for _, l := range lines {
+		assert.Equal(t, diffInsert, l.Op)
+	}
+	assert.Len(t, lines, 3)
+}
@@ -0,0 +1,235 @@
+package history
+
+// A component to view the revisions of a resource and what changed between them.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/ui"
+)
+
+const (
+	dateFormat = "2006-01-02 15:04:05"
+
+	// revisionsWidth is the width of the list of revisions.
+	revisionsWidth = 34
+
+	// noBase means that the selected revision is compared with the previous one.
+	noBase = -1
+)
+
+func History(state ui.ApplicationState, r resource.Resource, width, height int) Model {
+	vp := viewport.New(width-revisionsWidth, height)
+	// the other keys are used to select the revisions.
+	vp.KeyMap = viewport.KeyMap{
+		PageDown:     key.NewBinding(key.WithKeys("pgdown")),
+		PageUp:       key.NewBinding(key.WithKeys("pgup")),
+		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u")),
+	}
+
+	return Model{
+		state:    state,
+		resource: r,
+		viewport: vp,
+		base:     noBase,
+	}
+}
+
+type RevisionsMsg struct {
+	Err        error
+	Revisions  []resource.Revision
+	ResourceID string
+}
+
+// Init initializes the history component.
+func (m Model) Init() tea.Cmd {
+	return func() tea.Msg {
+		revisions, err := m.state.Store.ListRevisions(context.Background(), m.resource)
+		return RevisionsMsg{
+			Err:        err,
+			Revisions:  revisions,
+			ResourceID: m.resource.Metadata().ID,
+		}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case RevisionsMsg:
+		if msg.ResourceID != m.resource.Metadata().ID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.errorMsg = fmt.Sprintf("Error getting history: %s", msg.Err)
+			return m, nil
+		}
+		m.revisions = msg.Revisions
+		m.refreshDiff()
+		return m, nil
+	case tea.KeyMsg:
+		keys := m.state.Keys.HistoryKeyMap
+		switch {
+		case key.Matches(msg, keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+				m.refreshDiff()
+			}
+			return m, nil
+		case key.Matches(msg, keys.Down):
+			if m.cursor < len(m.revisions)-1 {
+				m.cursor++
+				m.refreshDiff()
+			}
+			return m, nil
+		case key.Matches(msg, keys.Compare):
+			if m.base == m.cursor {
+				m.base = noBase
+			} else {
+				m.base = m.cursor
+			}
+			m.refreshDiff()
+			return m, nil
+		}
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// compared returns the revisions being compared, from the oldest to the newest.
+// The oldest revision is empty for the first revision of the resource.
+func (m Model) compared() (resource.Revision, resource.Revision) {
+	to := m.revisions[m.cursor]
+
+	var from resource.Revision
+	switch {
+	case m.base != noBase:
+		from = m.revisions[m.base]
+	case m.cursor+1 < len(m.revisions):
+		from = m.revisions[m.cursor+1]
+	}
+
+	// revisions are sorted from the most recent to the oldest.
+	if from.Revision > to.Revision {
+		from, to = to, from
+	}
+
+	return from, to
+}
+
+func (m *Model) refreshDiff() {
+	if len(m.revisions) == 0 {
+		m.viewport.SetContent("")
+		return
+	}
+
+	from, to := m.compared()
+	m.viewport.SetContent(renderDiff(diffJSON(from.JSON, to.JSON)))
+	m.viewport.GotoTop()
+}
+
+func (m Model) View() string {
+	var body string
+
+	if len(m.revisions) == 0 {
+		body = lipgloss.Place(m.viewport.Width+revisionsWidth, m.viewport.Height, lipgloss.Center, lipgloss.Center, "No revisions yet")
+	} else {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, m.viewRevisions(), m.viewport.View())
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.viewHeader(),
+		m.state.Styles.BaseBorder.Width(m.viewport.Width+revisionsWidth).Render(body),
+	)
+}
+
+func (m Model) viewHeader() string {
+	metadata := m.resource.Metadata()
+	header := m.state.Styles.Title.Render("History of " + strings.ToLower(metadata.Type.String()) + " " + metadata.Name)
+
+	if len(m.revisions) > 0 {
+		from, to := m.compared()
+		if from.Revision == 0 {
+			header += m.state.Styles.Title.Render(fmt.Sprintf("(created at %s)", to.CreatedAt.Local().Format(dateFormat)))
+		} else {
+			header += m.state.Styles.Title.Render(fmt.Sprintf("(comparing %s with %s)",
+				from.CreatedAt.Local().Format(dateFormat),
+				to.CreatedAt.Local().Format(dateFormat)))
+		}
+	}
+
+	if m.errorMsg != "" {
+		header += "\n" + m.state.Styles.Error.Render(m.errorMsg)
+	}
+
+	return header
+}
+
+// viewRevisions renders the list of revisions around the cursor.
+func (m Model) viewRevisions() string {
+	start := 0
+	if m.cursor >= m.viewport.Height {
+		start = m.cursor - m.viewport.Height + 1
+	}
+	end := min(start+m.viewport.Height, len(m.revisions))
+
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		r := m.revisions[i]
+
+		marker := "  "
+		switch i {
+		case m.cursor:
+			marker = "> "
+		case m.base:
+			marker = "* "
+		}
+
+		status := ""
+		if r.Status != nil {
+			status = string(*r.Status)
+		}
+
+		line := marker + r.CreatedAt.Local().Format(dateFormat) + " " + status
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Bold(true).Render(line)
+		}
+		lines = append(lines, line)
+	}
+
+	return lipgloss.NewStyle().Width(revisionsWidth).MaxWidth(revisionsWidth).Render(strings.Join(lines, "\n"))
+}
+
+func (m *Model) SetDimensions(width, height int) {
+	m.viewport.Width = width - revisionsWidth
+	m.viewport.Height = height
+}
+
+// Model is the model for the history component.
+type Model struct {
+	// errorMsg is the error message to display.
+	errorMsg string
+	// state of the application
+	state ui.ApplicationState
+	// resource to view the history of
+	resource resource.Resource
+	// revisions of the resource, from the most recent to the oldest
+	revisions []resource.Revision
+	// cursor is the index of the selected revision
+	cursor int
+	// base is the index of the revision to compare the selected revision with
+	base int
+	// viewport to display the diff
+	viewport viewport.Model
+}
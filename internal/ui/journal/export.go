@@ -0,0 +1,167 @@
+package journal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+// exportFormat is the format of an exported file, picked from its extension.
+type exportFormat int
+
+const (
+	exportText exportFormat = iota
+	exportJSONLines
+	exportCSV
+)
+
+// exportDateFormat is the layout of the dates in the names of the exported files.
+const exportDateFormat = "20060102T150405"
+
+func formatFromPath(path string) exportFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return exportJSONLines
+	case ".csv":
+		return exportCSV
+	default:
+		return exportText
+	}
+}
+
+// exportName returns the default name of the exported file, such as "api-prod_20240102T150405-20240102T160405.log".
+func exportName(name string, start, end time.Time) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString("logs")
+	}
+	return fmt.Sprintf("%s_%s-%s.log", b.String(), start.Format(exportDateFormat), end.Format(exportDateFormat))
+}
+
+// exportedLog is a log in the JSON Lines format.
+type exportedLog struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Resource  string            `json:"resource,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Line      string            `json:"line"`
+}
+
+// exporter writes the logs in one of the export formats.
+type exporter struct {
+	format exportFormat
+	// columns are the fields written before the line, in the CSV format.
+	columns []string
+	// resourceName returns the name of the resource of a log, or an empty string if the logs are not merged.
+	resourceName func(l resource.Log) string
+	merged       bool
+}
+
+func (e exporter) write(w io.Writer, logs []resource.Log) error {
+	switch e.format {
+	case exportJSONLines:
+		return e.writeJSONLines(w, logs)
+	case exportCSV:
+		return e.writeCSV(w, logs)
+	case exportText:
+	}
+	return e.writeText(w, logs)
+}
+
+func (e exporter) writeText(w io.Writer, logs []resource.Log) error {
+	for _, l := range logs {
+		line := l.Timestamp.Format(time.RFC3339Nano) + " "
+		if e.merged {
+			line += e.resourceName(l) + " "
+		}
+		if _, err := io.WriteString(w, line+l.Line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e exporter) writeJSONLines(w io.Writer, logs []resource.Log) error {
+	enc := json.NewEncoder(w)
+	for _, l := range logs {
+		exported := exportedLog{Timestamp: l.Timestamp, Labels: l.Labels, Line: l.Line}
+		if e.merged {
+			exported.Resource = e.resourceName(l)
+		}
+		if err := enc.Encode(exported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e exporter) writeCSV(w io.Writer, logs []resource.Log) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"timestamp"}
+	if e.merged {
+		header = append(header, "resource")
+	}
+	header = append(header, e.columns...)
+	if err := cw.Write(append(header, "line")); err != nil {
+		return err
+	}
+
+	for _, l := range logs {
+		record := []string{l.Timestamp.Format(time.RFC3339Nano)}
+		if e.merged {
+			record = append(record, e.resourceName(l))
+		}
+		for _, name := range e.columns {
+			v, _ := l.Field(name)
+			record = append(record, v)
+		}
+		if err := cw.Write(append(record, l.Line)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportedMsg is sent once the logs were written to a file.
+type ExportedMsg struct {
+	Err   error
+	Path  string
+	Count int
+}
+
+// export writes the logs to a file, without overwriting an existing one.
+func export(path string, logs []resource.Log, e exporter) tea.Cmd {
+	return func() tea.Msg {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return ExportedMsg{Err: err, Path: path}
+		}
+
+		err = e.write(f, logs)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return ExportedMsg{Err: err, Path: path}
+		}
+		return ExportedMsg{Path: path, Count: len(logs)}
+	}
+}
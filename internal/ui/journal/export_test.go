@@ -0,0 +1,111 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportName(t *testing.T) {
+	start := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, "api-prod_20240102T150405-20240102T160405.log", exportName("api-prod", start, start.Add(time.Hour)))
+	assert.Equal(t, "my-app_20240102T150405-20240102T160405.log", exportName("my/app", start, start.Add(time.Hour)))
+}
+
+func TestExporter(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	logs := []resource.Log{
+		resource.NewLog(ts, "plain line", map[string]string{"stream": "stdout"}),
+		resource.NewLog(ts.Add(time.Second), `{"message":"failed","level":"error"}`, nil),
+	}
+	logs[1].ResourceID = "bar-id"
+
+	names := map[string]string{"bar-id": "bar"}
+	resourceName := func(l resource.Log) string { return names[l.ResourceID] }
+
+	tcs := []struct {
+		name     string
+		exporter exporter
+		expected string
+	}{
+		{
+			name:     "text",
+			exporter: exporter{format: exportText},
+			expected: "2024-01-02T15:04:05Z plain line\n" +
+				"2024-01-02T15:04:06Z {\"message\":\"failed\",\"level\":\"error\"}\n",
+		},
+		{
+			name:     "json lines",
+			exporter: exporter{format: exportJSONLines},
+			expected: `{"timestamp":"2024-01-02T15:04:05Z","labels":{"stream":"stdout"},"line":"plain line"}` + "\n" +
+				`{"timestamp":"2024-01-02T15:04:06Z","line":"{\"message\":\"failed\",\"level\":\"error\"}"}` + "\n",
+		},
+		{
+			name:     "csv with columns of merged logs",
+			exporter: exporter{format: exportCSV, columns: []string{"level"}, merged: true, resourceName: resourceName},
+			expected: "timestamp,resource,level,line\n" +
+				"2024-01-02T15:04:05Z,,,plain line\n" +
+				`2024-01-02T15:04:06Z,bar,error,"{""message"":""failed"",""level"":""error""}"` + "\n",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var b strings.Builder
+			require.NoError(t, tc.exporter.write(&b, logs))
+			assert.Equal(t, tc.expected, b.String())
+		})
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	assert.Equal(t, exportJSONLines, formatFromPath("logs.jsonl"))
+	assert.Equal(t, exportCSV, formatFromPath("logs.CSV"))
+	assert.Equal(t, exportText, formatFromPath("logs.log"))
+	assert.Equal(t, exportText, formatFromPath("logs"))
+}
+
+func TestModel_Export(t *testing.T) {
+	now := time.Now()
+	monitor := &fakeMonitor{logs: []resource.Log{
+		{Timestamp: now.Add(-2 * time.Second), Line: "first"},
+		{Timestamp: now.Add(-time.Second), Line: "second"},
+	}}
+
+	state := newTestState(monitor)
+	m := Journal(state, &testhelpers.MockResource{MetadataValue: resource.Metadata{Name: "api"}}, 80, 5)
+	m, _ = m.Update(m.fetchLogs()())
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	require.True(t, m.Editing())
+	assert.True(t, strings.HasPrefix(m.exportInput.Value(), "api_"), "the default path should be named after the resource")
+
+	path := filepath.Join(t.TempDir(), "logs.jsonl")
+	m.exportInput.SetValue(path)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.False(t, m.Editing())
+
+	m, _ = m.Update(cmd())
+	assert.Contains(t, m.View(), "Exported 2 logs")
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(b), "\n"))
+
+	t.Run("existing files are not overwritten", func(t *testing.T) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+		m.exportInput.SetValue(path)
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m, _ = m.Update(cmd())
+		assert.Contains(t, m.errorMsg, "Error exporting logs")
+	})
+}
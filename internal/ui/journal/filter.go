@@ -0,0 +1,23 @@
+package journal
+
+import (
+	"strconv"
+	"strings"
+)
+
+// toPipeline turns the input of the filter into a LogQL pipeline.
+// A LogQL line filter or pipeline is used as is, anything else is searched as plain text.
+func toPipeline(input string) string {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return ""
+	}
+
+	for _, prefix := range []string{"|", "!=", "!~"} {
+		if strings.HasPrefix(input, prefix) {
+			return input
+		}
+	}
+
+	return "|= " + strconv.Quote(input)
+}
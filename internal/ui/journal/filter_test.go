@@ -0,0 +1,27 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToPipeline(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"  ", ""},
+		{"error", `|= "error"`},
+		{`say "hi"`, `|= "say \"hi\""`},
+		{`|= "error"`, `|= "error"`},
+		{`!= "debug"`, `!= "debug"`},
+		{`|~ "time(out|d out)"`, `|~ "time(out|d out)"`},
+		{`| json | level="error"`, `| json | level="error"`},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, toPipeline(test.input), "input %q", test.input)
+	}
+}
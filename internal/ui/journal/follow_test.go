@@ -0,0 +1,156 @@
+package journal
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/observability/cockpit"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCockpitAPI is a stand-in for the Cockpit API and its Loki.
+// The range of a query_range is inclusive at both ends, so consecutive polls overlap.
+type fakeCockpitAPI struct {
+	url string
+
+	mutex  sync.Mutex
+	lines  []resource.Log
+	starts []time.Time
+}
+
+func (f *fakeCockpitAPI) push(logs ...resource.Log) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lines = append(f.lines, logs...)
+}
+
+func (f *fakeCockpitAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.URL.Path {
+	case "/cockpit/v1beta1/cockpit":
+		_, _ = fmt.Fprintf(w, `{"project_id": "project-id", "endpoints": {"logs_url": %q, "metrics_url": %q}}`, f.url, f.url)
+	case "/cockpit/v1beta1/tokens":
+		if r.Method == http.MethodGet {
+			_, _ = io.WriteString(w, `{"tokens": [], "total_count": 0}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": "token-id", "project_id": "project-id", "name": "scwtui", "secret_key": "secret"}`)
+	case "/loki/api/v1/query_range":
+		f.queryRange(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeCockpitAPI) queryRange(w http.ResponseWriter, r *http.Request) {
+	start, err := time.Parse(time.RFC3339Nano, r.URL.Query().Get("start"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	end, err := time.Parse(time.RFC3339Nano, r.URL.Query().Get("end"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	f.mutex.Lock()
+	f.starts = append(f.starts, start)
+	var matching []resource.Log
+	for _, l := range f.lines {
+		if !l.Timestamp.Before(start) && !l.Timestamp.After(end) {
+			matching = append(matching, l)
+		}
+	}
+	f.mutex.Unlock()
+
+	if limit > 0 && len(matching) > limit {
+		if r.URL.Query().Get("direction") == "backward" {
+			matching = matching[len(matching)-limit:]
+		} else {
+			matching = matching[:limit]
+		}
+	}
+
+	values := make([]string, 0, len(matching))
+	for _, l := range matching {
+		values = append(values, fmt.Sprintf(`["%d", %q]`, l.Timestamp.UnixNano(), l.Line))
+	}
+	_, _ = fmt.Fprintf(w, `{"status": "success", "data": {"resultType": "streams", "result": [{"stream": {}, "values": [%s]}]}}`, strings.Join(values, ","))
+}
+
+func TestModel_FollowCockpit(t *testing.T) {
+	api := &fakeCockpitAPI{}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	api.url = server.URL
+
+	client, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+	)
+	require.NoError(t, err)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	state := ui.ApplicationState{
+		Logger:  logger,
+		Monitor: cockpit.NewCockpit(logger, client, &cockpit.Config{}),
+		Keys:    ui.DefaultKeyMap(),
+	}
+	r := &testhelpers.MockResource{
+		MetadataValue: resource.Metadata{ID: "resource-id", ProjectID: "project-id", Type: resource.TypeRdbInstance},
+		CockpitMetadataValue: resource.CockpitMetadata{
+			CanViewLogs:  true,
+			ResourceID:   "resource-id",
+			ResourceType: "rdb_instance",
+		},
+	}
+
+	// the timestamps keep their nanoseconds, so that a rounded start would be noticed.
+	now := time.Now().Add(-time.Minute)
+	at := func(offset time.Duration, line string) resource.Log {
+		return resource.Log{Timestamp: now.Add(offset), Line: line}
+	}
+
+	api.push(at(0, "first"), at(time.Second, "second"), at(time.Second, "boundary"))
+
+	m := Journal(state, r, 80, 5)
+	m, _ = m.Update(m.fetchLogs()())
+	require.Equal(t, []string{"first", "second", "boundary"}, lines(m.tail.logs))
+
+	poll := func(logs ...resource.Log) {
+		api.push(logs...)
+		m, _ = m.Update(m.fetchNewLogs(m.tail.since(), time.Now()))
+	}
+
+	// lines at the timestamp of the last poll arrive late, one of them with the same text.
+	poll(at(time.Second, "late"), at(time.Second, "boundary"), at(2*time.Second, "third"))
+	// nothing new, the boundary is returned again.
+	poll()
+	poll(at(2*time.Second, "third"), at(3*time.Second, "fourth"))
+
+	assert.Equal(t, []string{"first", "second", "boundary", "late", "boundary", "third", "third", "fourth"}, lines(m.tail.logs),
+		"the lines should be neither duplicated nor dropped at the boundary of the polls")
+
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	require.Len(t, api.starts, 4)
+	for i, want := range []time.Time{now.Add(time.Second), now.Add(2 * time.Second), now.Add(2 * time.Second)} {
+		assert.True(t, api.starts[i+1].Equal(want), "poll %d should start at the timestamp of the last log, got %s", i+1, api.starts[i+1])
+	}
+}
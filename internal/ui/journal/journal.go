@@ -6,10 +6,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -27,88 +30,670 @@ const (
 	StatusLoaded
 )
 
+// fetchTimeout is the maximum duration of a query.
+const fetchTimeout = 10 * time.Second
+
+// pageSize is the number of logs loaded at once.
+// The older logs are loaded when scrolling to the top.
+const pageSize = 1000
+
+func newModel(state ui.ApplicationState, width, height int) Model {
+	ti := textinput.New()
+	ti.Prompt = "Time range: "
+	ti.Placeholder = customRangeLayout + customRangeSeparator + customRangeLayout
+
+	fi := textinput.New()
+	fi.Prompt = "Filter: "
+	fi.Placeholder = `text, or a LogQL pipeline such as |~ "timeout|refused"`
+
+	ci := textinput.New()
+	ci.Prompt = "Columns: "
+
+	ei := textinput.New()
+	ei.Prompt = "Export to: "
+	ei.Placeholder = "a .log file for plain text, .jsonl for JSON Lines or .csv"
+
+	return Model{
+		state:       state,
+		viewport:    viewport.New(width, height),
+		detail:      viewport.New(width, height),
+		spinner:     spinner.New(spinner.WithSpinner(spinner.Line)),
+		status:      StatusLoading,
+		preset:      defaultPreset,
+		following:   true,
+		timeRange:   presets[defaultPreset],
+		rangeInput:  ti,
+		filterInput: fi,
+		columnInput: ci,
+		exportInput: ei,
+	}
+}
+
+// Journal shows the logs of a resource.
 func Journal(state ui.ApplicationState, r resource.Resource, width, height int) Model {
-	m := Model{
-		state:    state,
-		resource: r,
-		viewport: viewport.New(width, height),
-		spinner:  spinner.New(spinner.WithSpinner(spinner.Line)),
-		status:   StatusLoading,
+	m := newModel(state, width, height)
+	m.id = r.Metadata().ID
+	m.name = r.Metadata().Name
+	m.title = "Logs for " + describe(r)
+	m.resources = []resource.Resource{r}
+	return m
+}
+
+// Merged shows the logs of several resources, interleaved by timestamp.
+func Merged(state ui.ApplicationState, resources []resource.Resource, width, height int) Model {
+	ids := make([]string, 0, len(resources))
+	for _, r := range resources {
+		ids = append(ids, r.Metadata().ID)
 	}
 
+	m := newModel(state, width, height)
+	m.id = strings.Join(ids, ",")
+	m.name = "logs"
+	m.title = fmt.Sprintf("Logs for %d resources", len(resources))
+	m.setResources(resources)
 	return m
 }
 
+// MergedChildren shows the logs of all the resources of a project or a namespace, interleaved by timestamp.
+// The resources are loaded from the store first.
+func MergedChildren(state ui.ApplicationState, parent resource.Resource, width, height int) Model {
+	m := newModel(state, width, height)
+	m.id = parent.Metadata().ID
+	m.name = parent.Metadata().Name
+	m.title = "Logs for " + describe(parent)
+	m.parent = parent
+	return m
+}
+
+// setResources sets the resources of a merged journal, skipping the ones without logs.
+func (m *Model) setResources(resources []resource.Resource) {
+	m.resources = nil
+	m.skipped = nil
+	for _, r := range resources {
+		if r.CockpitMetadata().CanViewLogs {
+			m.resources = append(m.resources, r)
+		} else {
+			m.skipped = append(m.skipped, r)
+		}
+	}
+
+	// the lines are prefixed with the name of their resource.
+	m.sources = make(map[string]source, len(m.resources))
+	m.sourceWidth = 0
+	for i, r := range m.resources {
+		name := runewidth.Truncate(r.Metadata().Name, maxSourceWidth, "…")
+		m.sources[r.Metadata().ID] = source{resource: r.Metadata().Name, name: name, style: m.sourceStyle(i)}
+		m.sourceWidth = max(m.sourceWidth, runewidth.StringWidth(name))
+	}
+}
+
+type childrenMsg struct {
+	id        string
+	resources []resource.Resource
+	err       error
+}
+
+func loadChildren(state ui.ApplicationState, parent resource.Resource) tea.Cmd {
+	return func() tea.Msg {
+		metadata := parent.Metadata()
+		resources, err := state.Store.ListProjectResources(context.Background(), metadata.ProjectID)
+		if err != nil {
+			return childrenMsg{id: metadata.ID, err: err}
+		}
+		return childrenMsg{id: metadata.ID, resources: resource.Descendants(parent, resources)}
+	}
+}
+
 type LogsMsg struct {
-	Err        error
-	Logs       []resource.Log
+	Err  error
+	Logs []resource.Log
+	// ResourceID is the ID of the resource of the journal, or the IDs of its resources when they are merged.
 	ResourceID string
+	// generation is used to ignore the logs of a previous time range.
+	generation int
+	// poll is set for the new logs fetched while following the logs.
+	poll int
+	// incremental is true when the logs only contain the new logs.
+	incremental bool
+	// hasMore is true when there are older logs in the time range.
+	hasMore bool
+	// end is the end of the time range of the query.
+	end time.Time
 }
 
-func refreshEvery(state ui.ApplicationState, r resource.Resource, d time.Duration) tea.Cmd {
-	return tea.Every(d, func(t time.Time) tea.Msg {
-		ctx, cancel := context.WithDeadline(context.Background(), t.Add(d))
+func (m Model) fetchLogs() tea.Cmd {
+	state, resources, id, generation := m.state, m.resources, m.id, m.generation
+	query := m.timeRange.query(time.Now())
+	query.Filter = m.filter
+	query.Limit = pageSize
+	query.Direction = resource.LogsBackward
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
 		defer cancel()
 
-		logs, err := state.Monitor.Logs(ctx, r)
+		logs, hasMore, err := queryLogs(ctx, state.Monitor, resources, query)
 		if err != nil {
 			state.Logger.Error("journal: failed to get logs", slog.String("error", err.Error()))
-			return LogsMsg{Err: err}
 		}
-		return LogsMsg{Logs: logs, ResourceID: r.Metadata().ID}
+		return LogsMsg{Err: err, Logs: logs, ResourceID: id, generation: generation, hasMore: hasMore, end: query.End}
+	}
+}
+
+// followLogs fetches the logs that are more recent than since, after a delay.
+func (m Model) followLogs(since time.Time) tea.Cmd {
+	return tea.Tick(tailInterval, func(t time.Time) tea.Msg {
+		return m.fetchNewLogs(since, t)
 	})
 }
 
+func (m Model) fetchNewLogs(since, now time.Time) LogsMsg {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	logs, _, err := queryLogs(ctx, m.state.Monitor, m.resources, resource.LogsQuery{
+		Start:     since,
+		End:       now,
+		Direction: resource.LogsForward,
+		Filter:    m.filter,
+	})
+	if err != nil {
+		m.state.Logger.Error("journal: failed to follow logs", slog.String("error", err.Error()))
+	}
+	return LogsMsg{
+		Err:         err,
+		Logs:        logs,
+		ResourceID:  m.id,
+		generation:  m.generation,
+		poll:        m.poll,
+		incremental: true,
+	}
+}
+
+// OlderLogsMsg holds a page of logs older than the ones of the journal.
+type OlderLogsMsg struct {
+	Err  error
+	Logs []resource.Log
+	// generation is used to ignore the logs of a previous time range.
+	generation int
+	// hasMore is true when there are even older logs in the time range.
+	hasMore bool
+}
+
+// fetchOlderLogs fetches the page of logs before the first log of the journal.
+func (m *Model) fetchOlderLogs() tea.Cmd {
+	m.loadingOlder = true
+
+	state, resources, generation := m.state, m.resources, m.generation
+	query := resource.LogsQuery{
+		Start:     m.timeRange.query(m.loadedAt).Start,
+		End:       m.tail.oldest(),
+		Limit:     pageSize,
+		Direction: resource.LogsBackward,
+		Filter:    m.filter,
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+
+		logs, hasMore, err := queryLogs(ctx, state.Monitor, resources, query)
+		if err != nil {
+			state.Logger.Error("journal: failed to get older logs", slog.String("error", err.Error()))
+		}
+		return OlderLogsMsg{Err: err, Logs: logs, generation: generation, hasMore: hasMore}
+	}
+}
+
+// maybeFetchOlderLogs loads the older logs once the user scrolled to the top.
+func (m *Model) maybeFetchOlderLogs() tea.Cmd {
+	if m.status != StatusLoaded || !m.hasOlder || m.loadingOlder || !m.viewport.AtTop() {
+		return nil
+	}
+	return m.fetchOlderLogs()
+}
+
+// prependOlderLogs adds a page of older logs, keeping the position of the user.
+func (m *Model) prependOlderLogs(msg OlderLogsMsg) {
+	m.loadingOlder = false
+	if msg.Err != nil {
+		m.errorMsg = fmt.Sprintf("Error getting older logs: %s", msg.Err)
+		// the logs of the other resources are still shown, when they are merged.
+		if len(msg.Logs) == 0 {
+			return
+		}
+	}
+
+	added := m.tail.prepend(msg.Logs)
+	// a page without any new log means that the beginning of the range was reached,
+	// or that there are more logs with the same timestamp than the size of a page.
+	m.hasOlder = msg.hasMore && len(added) > 0
+	if len(added) == 0 {
+		return
+	}
+
+	rendered := make([]string, 0, len(added))
+	height := 0
+	for _, l := range added {
+		r := m.renderLog(l, false)
+		rendered = append(rendered, r)
+		height += lipgloss.Height(r)
+	}
+	m.rendered = append(rendered, m.rendered...)
+	m.cursor = min(m.cursor+len(added), len(m.tail.logs)-1)
+	m.refreshContent()
+	m.viewport.SetYOffset(m.viewport.YOffset + height)
+}
+
+// rebuild renders all the logs again, for instance when the columns or the width change.
+func (m *Model) rebuild() {
+	m.columnWidths = columnWidths(m.tail.logs, m.columns)
+	m.rendered = make([]string, 0, len(m.tail.logs))
+	for i, l := range m.tail.logs {
+		m.rendered = append(m.rendered, m.renderLog(l, i == m.cursor))
+	}
+	m.refreshContent()
+}
+
+// refreshContent sets the content of the viewport from the rendered logs.
+func (m *Model) refreshContent() {
+	m.offsets = make([]int, len(m.rendered))
+	line := 0
+	for i, r := range m.rendered {
+		m.offsets[i] = line
+		line += lipgloss.Height(r)
+	}
+	m.viewport.SetContent(strings.Join(m.rendered, "\n"))
+}
+
+// selectLog moves the cursor to a log, and scrolls to keep it visible.
+func (m *Model) selectLog(i int) {
+	if len(m.tail.logs) == 0 {
+		return
+	}
+	i = max(0, min(i, len(m.tail.logs)-1))
+
+	if i != m.cursor {
+		if m.cursor >= 0 && m.cursor < len(m.rendered) {
+			m.rendered[m.cursor] = m.renderLog(m.tail.logs[m.cursor], false)
+		}
+		m.cursor = i
+		m.rendered[i] = m.renderLog(m.tail.logs[i], true)
+		m.refreshContent()
+	}
+
+	top := m.offsets[i]
+	bottom := top + lipgloss.Height(m.rendered[i]) - 1
+	if top < m.viewport.YOffset {
+		m.viewport.SetYOffset(top)
+	} else if bottom >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(bottom - m.viewport.Height + 1)
+	}
+}
+
+// selectVisibleLog moves the cursor to the closest visible log, once the viewport was scrolled.
+func (m *Model) selectVisibleLog() {
+	if m.cursor < 0 || m.cursor >= len(m.offsets) {
+		return
+	}
+
+	top, bottom := m.viewport.YOffset, m.viewport.YOffset+m.viewport.Height
+	switch {
+	case m.offsets[m.cursor] < top:
+		// the first log that starts in the viewport.
+		m.selectLog(sort.SearchInts(m.offsets, top))
+	case m.offsets[m.cursor] >= bottom:
+		// the last log that starts in the viewport.
+		m.selectLog(sort.SearchInts(m.offsets, bottom) - 1)
+	}
+}
+
+// expand shows the selected log as pretty-printed JSON.
+func (m *Model) expand() {
+	if m.cursor < 0 || m.cursor >= len(m.tail.logs) {
+		return
+	}
+	m.expanded = true
+	m.detail.SetContent(m.renderPrettyLog(m.tail.logs[m.cursor]))
+	m.detail.GotoTop()
+}
+
+// follow fetches the new logs, if they are followed.
+func (m *Model) follow() tea.Cmd {
+	// an absolute time range does not change.
+	if !m.following || !m.timeRange.relative() {
+		return nil
+	}
+
+	since := m.tail.since()
+	if since.IsZero() {
+		since = m.loadedAt
+	}
+	// the logs have not been loaded yet.
+	if since.IsZero() {
+		return nil
+	}
+	return m.followLogs(since)
+}
+
+// toggleFollow pauses or resumes following the new logs.
+func (m *Model) toggleFollow() tea.Cmd {
+	m.following = !m.following
+	// stop the previous polls, if any.
+	m.poll++
+	// otherwise, the polls start once the logs are loaded.
+	if m.status != StatusLoaded {
+		return nil
+	}
+	return m.follow()
+}
+
 // Init initializes the journal component.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
-		m.spinner.Tick,
-		refreshEvery(m.state, m.resource, 10*time.Second),
-	)
+	if m.parent != nil {
+		return tea.Batch(m.spinner.Tick, loadChildren(m.state, m.parent))
+	}
+	return tea.Batch(m.spinner.Tick, m.fetchLogs())
+}
+
+// Editing returns true if the user is typing a custom time range, a filter, the columns or the path of an export.
+func (m Model) Editing() bool {
+	return m.rangeInput.Focused() || m.filterInput.Focused() || m.columnInput.Focused() || m.exportInput.Focused()
+}
+
+// Expanded returns true if a log is shown as pretty-printed JSON.
+func (m Model) Expanded() bool {
+	return m.expanded
+}
+
+// setTimeRange reloads the logs for another time range.
+func (m *Model) setTimeRange(tr timeRange) tea.Cmd {
+	m.timeRange = tr
+	return m.reload()
+}
+
+// reload loads the logs again, ignoring the logs of the previous queries.
+func (m *Model) reload() tea.Cmd {
+	m.generation++
+	m.status = StatusLoading
+	m.errorMsg = ""
+	return tea.Batch(m.spinner.Tick, m.fetchLogs())
+}
+
+func (m Model) updateFilterInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.filterInput.Blur()
+		m.filterInput.SetValue(m.filterValue)
+		return m, nil
+	case tea.KeyEnter:
+		m.filterInput.Blur()
+		m.filterValue = m.filterInput.Value()
+		m.filter = toPipeline(m.filterValue)
+		return m, m.reload()
+	}
+
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) updateColumnInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.columnInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		m.columnInput.Blur()
+		m.columns = parseColumns(m.columnInput.Value())
+		m.rebuild()
+		m.selectLog(m.cursor)
+		return m, nil
+	}
+
+	m.columnInput, cmd = m.columnInput.Update(msg)
+	return m, cmd
+}
+
+// editColumns starts typing the columns, suggesting the fields of the logs.
+func (m *Model) editColumns() tea.Cmd {
+	names := fieldNames(m.tail.logs)
+	if len(names) > maxSuggestedFields {
+		names = append(names[:maxSuggestedFields], "...")
+	}
+	m.columnInput.Placeholder = "comma-separated fields"
+	if len(names) > 0 {
+		m.columnInput.Placeholder += ", such as " + strings.Join(names, ", ")
+	}
+	m.columnInput.SetValue(strings.Join(m.columns, ", "))
+	m.columnInput.CursorEnd()
+	return m.columnInput.Focus()
+}
+
+func (m Model) updateExportInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.exportInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		path := strings.TrimSpace(m.exportInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		m.exportInput.Blur()
+		m.exportMsg = "Exporting logs..."
+		// the logs are copied, as the new ones keep coming while the file is written.
+		logs := append([]resource.Log(nil), m.tail.logs...)
+		return m, export(path, logs, m.exporter(formatFromPath(path)))
+	}
+
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
+
+// editExport starts typing the path of the export, with a default named after the resource and the time range.
+func (m *Model) editExport() tea.Cmd {
+	query := m.timeRange.query(m.loadedAt)
+	m.exportInput.SetValue(exportName(m.name, query.Start, query.End))
+	m.exportInput.CursorEnd()
+	m.exportMsg = ""
+	return m.exportInput.Focus()
+}
+
+// exporter returns the exporter of the loaded logs, with the selected columns.
+func (m Model) exporter(format exportFormat) exporter {
+	sources := m.sources
+	return exporter{
+		format:  format,
+		columns: m.columns,
+		merged:  sources != nil,
+		resourceName: func(l resource.Log) string {
+			return sources[l.ResourceID].resource
+		},
+	}
+}
+
+func (m Model) updateRangeInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.rangeInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		tr, err := parseTimeRange(m.rangeInput.Value(), time.Now(), time.Local)
+		if err != nil {
+			m.rangeError = err.Error()
+			return m, nil
+		}
+		m.rangeError = ""
+		m.rangeInput.Blur()
+		m.preset = -1
+		return m, m.setTimeRange(tr)
+	}
+
+	m.rangeInput, cmd = m.rangeInput.Update(msg)
+	return m, cmd
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.rangeInput.Focused() {
+			return m.updateRangeInput(msg)
+		}
+		if m.filterInput.Focused() {
+			return m.updateFilterInput(msg)
+		}
+		if m.columnInput.Focused() {
+			return m.updateColumnInput(msg)
+		}
+		if m.exportInput.Focused() {
+			return m.updateExportInput(msg)
+		}
+		if m.expanded {
+			if key.Matches(msg, m.state.Keys.Expand, m.state.Keys.Quit) {
+				m.expanded = false
+				return m, nil
+			}
+			m.detail, cmd = m.detail.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, m.state.Keys.TimeRange):
+			m.preset = nextPreset(m.preset)
+			return m, m.setTimeRange(presets[m.preset])
+		case key.Matches(msg, m.state.Keys.CustomTimeRange):
+			m.rangeError = ""
+			return m, m.rangeInput.Focus()
+		case key.Matches(msg, m.state.Keys.Filter):
+			return m, m.filterInput.Focus()
+		case key.Matches(msg, m.state.Keys.Follow):
+			return m, m.toggleFollow()
+		case key.Matches(msg, m.state.Keys.Columns):
+			return m, m.editColumns()
+		case key.Matches(msg, m.state.Keys.Export):
+			if m.status != StatusLoaded {
+				return m, nil
+			}
+			return m, m.editExport()
+		case key.Matches(msg, m.state.Keys.Expand):
+			m.expand()
+			return m, nil
+		case key.Matches(msg, m.state.Keys.PreviousLog):
+			m.selectLog(m.cursor - 1)
+			return m, m.maybeFetchOlderLogs()
+		case key.Matches(msg, m.state.Keys.NextLog):
+			m.selectLog(m.cursor + 1)
+			return m, nil
+		}
+	case childrenMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.status = StatusLoaded
+			m.errorMsg = fmt.Sprintf("Error listing resources: %s", msg.err)
+			return m, nil
+		}
+		m.setResources(msg.resources)
+		return m, m.fetchLogs()
 	case LogsMsg:
 		// this can sometimes happen if the user switches to the logs tab
 		// of another resource before the logs for the previous resource
 		// have been loaded.
-		if msg.ResourceID != m.resource.Metadata().ID {
+		if msg.ResourceID != m.id || msg.generation != m.generation {
+			return m, nil
+		}
+
+		if msg.incremental && msg.poll != m.poll {
 			return m, nil
 		}
 
+		m.errorMsg = ""
 		if msg.Err != nil {
 			m.errorMsg = fmt.Sprintf("Error getting logs: %s", msg.Err)
-			return m, nil
+			// the logs of the other resources are still shown, when they are merged.
+			if len(msg.Logs) == 0 {
+				if !msg.incremental {
+					// the logs are only followed once they could be loaded,
+					// otherwise a bad query would be sent again at every poll.
+					m.status = StatusLoaded
+					m.loadingOlder = false
+					return m, nil
+				}
+				return m, m.follow()
+			}
 		}
-		if m.status != StatusLoaded {
+
+		if !msg.incremental {
 			m.status = StatusLoaded
+			m.loadedAt = msg.end
+			m.tail.reset(msg.Logs)
+			m.tail.trim()
+			m.cursor = max(len(m.tail.logs)-1, 0)
+			m.rebuild()
+			m.viewport.GotoBottom()
+			m.hasOlder = msg.hasMore
+			m.loadingOlder = false
+			return m, m.follow()
 		}
-		m.viewport.SetContent(m.buildViewPortContent(msg.Logs))
-		return m, refreshEvery(m.state, m.resource, 10*time.Second)
+
+		// keep the position of the user if they scrolled up, or selected another log.
+		atTail := len(m.tail.logs) == 0 || (m.cursor == len(m.tail.logs)-1 && m.viewport.AtBottom())
+		count := len(m.tail.logs)
+		added := m.tail.append(msg.Logs)
+		if len(added) == 0 {
+			return m, m.follow()
+		}
+
+		if m.tail.trim() {
+			// the dropped logs can be loaded again by scrolling up.
+			m.hasOlder = true
+			m.cursor = max(m.cursor-(count+len(added)-len(m.tail.logs)), 0)
+			m.rebuild()
+		} else {
+			for _, l := range added {
+				m.rendered = append(m.rendered, m.renderLog(l, len(m.rendered) == m.cursor))
+			}
+			m.refreshContent()
+		}
+		if atTail {
+			m.selectLog(len(m.tail.logs) - 1)
+			m.viewport.GotoBottom()
+		}
+		return m, m.follow()
+	case ExportedMsg:
+		if msg.Err != nil {
+			m.exportMsg = ""
+			m.errorMsg = fmt.Sprintf("Error exporting logs: %s", msg.Err)
+			return m, nil
+		}
+		m.exportMsg = fmt.Sprintf("Exported %d logs to %s", msg.Count, msg.Path)
+		return m, nil
+	case OlderLogsMsg:
+		if msg.generation != m.generation {
+			return m, nil
+		}
+		m.prependOlderLogs(msg)
+		return m, nil
 	case spinner.TickMsg:
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 	}
 
 	m.viewport, cmd = m.viewport.Update(msg)
+	m.selectVisibleLog()
 
-	return m, cmd
-}
-
-const (
-	dateFormat = "2006-01-02 15:04:05"
-)
-
-func (m Model) buildViewPortContent(logs []resource.Log) string {
-	var b strings.Builder
-	for _, l := range logs {
-		b.WriteString(runewidth.Wrap(l.Timestamp.Format(dateFormat)+": "+l.Line, m.viewport.Width))
-		b.WriteRune('\n')
-	}
-	return b.String()
+	return m, tea.Batch(cmd, m.maybeFetchOlderLogs())
 }
 
 func (m Model) View() string {
@@ -119,6 +704,9 @@ func (m Model) View() string {
 		body = lipgloss.Place(m.viewport.Width, m.viewport.Height, lipgloss.Center, lipgloss.Center, m.spinner.View())
 	case StatusLoaded:
 		body = m.viewport.View()
+		if m.expanded {
+			body = m.detail.View()
+		}
 	}
 
 	header := m.viewHeader()
@@ -130,8 +718,41 @@ func (m Model) View() string {
 }
 
 func (m Model) viewHeader() string {
-	metadata := m.resource.Metadata()
-	header := m.state.Styles.Title.Render("Logs for " + strings.ToLower(metadata.Type.String()) + " " + metadata.Name)
+	header := m.state.Styles.Title.Render(m.title)
+	header += m.state.Styles.Title.Copy().Bold(false).Render("(" + m.timeRange.String() + ")")
+	if m.timeRange.relative() && !m.following {
+		header += m.state.Styles.Title.Copy().Bold(false).Render("(paused)")
+	}
+	if m.expanded {
+		header += m.state.Styles.Title.Copy().Bold(false).Render("(enter or esc to collapse)")
+	}
+	if m.rangeInput.Focused() {
+		header += "\n" + m.rangeInput.View()
+	}
+	if m.filterInput.Focused() {
+		header += "\n" + m.filterInput.View()
+	} else if m.filter != "" {
+		header += "\n" + m.state.Styles.Title.Copy().Bold(false).Render("Filter: "+m.filter)
+	}
+	if m.columnInput.Focused() {
+		header += "\n" + m.columnInput.View()
+	} else if len(m.columns) > 0 {
+		header += "\n" + m.state.Styles.Title.Copy().Bold(false).Render("Columns: "+strings.Join(m.columns, ", "))
+	}
+	if m.exportInput.Focused() {
+		header += "\n" + m.exportInput.View()
+	} else if m.exportMsg != "" {
+		header += "\n" + m.state.Styles.Title.Copy().Bold(false).Render(m.exportMsg)
+	}
+	if m.status == StatusLoaded && len(m.skipped) > 0 {
+		header += "\n" + m.state.Styles.Title.Copy().Bold(false).Render(m.skippedNote())
+	}
+	if m.loadingOlder {
+		header += "\n" + m.state.Styles.Title.Copy().Bold(false).Render("Loading older logs...")
+	}
+	if m.rangeError != "" {
+		header += "\n" + m.state.Styles.Error.Render(m.rangeError)
+	}
 	if m.errorMsg != "" {
 		header += "\n" + m.state.Styles.Error.Render(m.errorMsg)
 	}
@@ -140,8 +761,14 @@ func (m Model) viewHeader() string {
 }
 
 func (m *Model) SetDimensions(width, height int) {
-	m.viewport.Width = width
+	if width != m.viewport.Width {
+		m.viewport.Width = width
+		// the lines are wrapped to the width of the viewport.
+		m.rebuild()
+	}
 	m.viewport.Height = height
+	m.detail.Width = width
+	m.detail.Height = height
 }
 
 // Model is the model for the confirm component.
@@ -150,8 +777,19 @@ type Model struct {
 	errorMsg string
 	// state is the context.
 	state ui.ApplicationState
-	// resource is the resource to monitor.
-	resource resource.Resource
+	// id identifies the journal in the messages, and title is shown in its header.
+	id    string
+	title string
+	// name is used to name the exported files.
+	name string
+	// resources are the resources to monitor, and skipped the ones without logs.
+	resources []resource.Resource
+	skipped   []resource.Resource
+	// parent is set when the resources are the ones of a project or a namespace, and are not loaded yet.
+	parent resource.Resource
+	// sources are the prefixes of the lines of each resource, when their logs are merged.
+	sources     map[string]source
+	sourceWidth int
 	// viewport is the viewport.
 	viewport viewport.Model
 
@@ -159,4 +797,56 @@ type Model struct {
 	status Status
 	// spinner is the spinner to display while loading.
 	spinner spinner.Model
+
+	// timeRange is the time range of the logs.
+	timeRange timeRange
+	// preset is the index of the preset time range, or -1 for a custom range.
+	preset int
+	// generation is incremented each time the logs are reloaded for another time range.
+	generation int
+
+	// rangeInput is used to type a custom time range.
+	rangeInput textinput.Model
+	// rangeError is the error of the custom time range, if any.
+	rangeError string
+
+	// filterInput is used to type a filter.
+	filterInput textinput.Model
+	// filterValue is the filter, as typed by the user.
+	filterValue string
+	// filter is the LogQL pipeline appended to the query.
+	filter string
+
+	// tail holds the logs, so that only the new ones are fetched when following them.
+	tail tail
+	// rendered are the rendered logs, and offsets the line at which each of them starts.
+	rendered []string
+	offsets  []int
+	// cursor is the index of the selected log.
+	cursor int
+	// following is true when the new logs are fetched periodically.
+	following bool
+	// poll is incremented to stop the previous polls of the new logs.
+	poll int
+	// loadedAt is the end of the time range when the logs were loaded.
+	loadedAt time.Time
+
+	// hasOlder is true when there may be older logs in the time range.
+	hasOlder bool
+	// loadingOlder is true while the older logs are being loaded.
+	loadingOlder bool
+
+	// columnInput is used to type the columns.
+	columnInput textinput.Model
+	// columns are the fields of the logs shown before their message.
+	columns      []string
+	columnWidths []int
+
+	// exportInput is used to type the path of an export, and exportMsg is the outcome of the last one.
+	exportInput textinput.Model
+	exportMsg   string
+
+	// expanded is true when the selected log is shown as pretty-printed JSON in detail.
+	expanded bool
+	detail   viewport.Model
 }
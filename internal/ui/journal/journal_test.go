@@ -0,0 +1,247 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMonitor returns the logs of the time range, including its end, like Loki.
+type fakeMonitor struct {
+	mu      sync.Mutex
+	logs    []resource.Log
+	queries []resource.LogsQuery
+	// perResource are the logs of each resource, by ID, when they are merged.
+	perResource map[string][]resource.Log
+	// err is returned by all the queries, if set.
+	err error
+}
+
+func (f *fakeMonitor) Logs(_ context.Context, r resource.Resource, query resource.LogsQuery) ([]resource.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, query)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	all := f.logs
+	if f.perResource != nil {
+		all = f.perResource[r.Metadata().ID]
+	}
+
+	var logs []resource.Log
+	for _, l := range all {
+		if l.Timestamp.Before(query.Start) || (!query.End.IsZero() && l.Timestamp.After(query.End)) {
+			continue
+		}
+		logs = append(logs, l)
+	}
+
+	if query.Limit > 0 && len(logs) > query.Limit {
+		if query.Direction == resource.LogsBackward {
+			logs = logs[len(logs)-query.Limit:]
+		} else {
+			logs = logs[:query.Limit]
+		}
+	}
+	return logs, nil
+}
+
+func (f *fakeMonitor) Metrics(context.Context, resource.Resource, resource.MetricsQuery) ([]resource.Series, error) {
+	return nil, nil
+}
+
+func newTestState(monitor resource.Monitorer) ui.ApplicationState {
+	return ui.ApplicationState{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Monitor: monitor,
+		Keys:    ui.DefaultKeyMap(),
+	}
+}
+
+func TestModel_Follow(t *testing.T) {
+	now := time.Now()
+	monitor := &fakeMonitor{}
+	for i := 0; i < 20; i++ {
+		monitor.logs = append(monitor.logs, resource.Log{
+			Timestamp: now.Add(time.Duration(i-20) * time.Second),
+			Line:      fmt.Sprintf("line %d", i),
+		})
+	}
+
+	state := newTestState(monitor)
+	m := Journal(state, &testhelpers.MockResource{}, 80, 5)
+
+	msg := m.fetchLogs()()
+	m, cmd := m.Update(msg)
+	require.NotNil(t, cmd, "the new logs should be followed")
+	require.Len(t, m.tail.logs, 20)
+	assert.True(t, m.viewport.AtBottom())
+
+	follow := func(logs ...resource.Log) {
+		monitor.logs = append(monitor.logs, logs...)
+		m, _ = m.Update(m.fetchNewLogs(m.tail.since(), now.Add(time.Minute)))
+
+		query := monitor.queries[len(monitor.queries)-1]
+		assert.Equal(t, resource.LogsForward, query.Direction)
+	}
+
+	t.Run("only the new logs are appended", func(t *testing.T) {
+		follow(resource.Log{Timestamp: now, Line: "new line"})
+
+		assert.Len(t, m.tail.logs, 21)
+		assert.Equal(t, "new line", m.tail.logs[20].Line)
+		assert.True(t, m.viewport.AtBottom())
+	})
+
+	t.Run("the position is kept when scrolled up", func(t *testing.T) {
+		m.viewport.GotoTop()
+		follow(resource.Log{Timestamp: now.Add(time.Second), Line: "another line"})
+
+		assert.Len(t, m.tail.logs, 22)
+		assert.Equal(t, 0, m.viewport.YOffset)
+	})
+
+	t.Run("paused logs are not followed", func(t *testing.T) {
+		cmd := m.toggleFollow()
+		assert.Nil(t, cmd)
+
+		// the polls that were scheduled before the pause are ignored.
+		m, _ = m.Update(LogsMsg{
+			Logs:        []resource.Log{{Timestamp: now.Add(2 * time.Second), Line: "late line"}},
+			generation:  m.generation,
+			poll:        m.poll - 1,
+			incremental: true,
+		})
+		assert.Len(t, m.tail.logs, 22)
+	})
+}
+
+func TestModel_FailedLoad(t *testing.T) {
+	monitor := &fakeMonitor{err: errors.New("invalid filter")}
+	m := Journal(newTestState(monitor), &testhelpers.MockResource{}, 80, 5)
+
+	m, cmd := m.Update(m.fetchLogs()())
+	assert.Nil(t, cmd, "the logs should not be followed after a failed load")
+	assert.Equal(t, StatusLoaded, m.status)
+	assert.Contains(t, m.errorMsg, "invalid filter")
+
+	m.toggleFollow()
+	assert.Nil(t, m.toggleFollow(), "the logs should not be followed before they are loaded")
+}
+
+func TestModel_OlderLogs(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	monitor := &fakeMonitor{}
+	for i := 0; i < 2*pageSize+pageSize/2; i++ {
+		// several logs share the same timestamp, including at the boundaries of the pages.
+		monitor.logs = append(monitor.logs, resource.Log{
+			Timestamp: now.Add(-time.Hour).Add(time.Duration(i/3) * time.Second),
+			Line:      fmt.Sprintf("line %d", i),
+		})
+	}
+
+	state := newTestState(monitor)
+	m := Journal(state, &testhelpers.MockResource{}, 80, 5)
+	m, _ = m.Update(m.fetchLogs()())
+	require.Len(t, m.tail.logs, pageSize)
+	require.True(t, m.hasOlder)
+
+	for pages := 0; m.hasOlder; pages++ {
+		require.Less(t, pages, 10, "the older logs should be loaded in a few pages")
+
+		m.selectLog(0)
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+		require.True(t, m.loadingOlder)
+
+		yoffset := m.viewport.YOffset
+		selected := m.tail.logs[m.cursor]
+		for _, msg := range collect[OlderLogsMsg](cmd) {
+			m, _ = m.Update(msg)
+		}
+		if m.hasOlder {
+			assert.Greater(t, m.viewport.YOffset, yoffset, "the position should be kept")
+		}
+		assert.Equal(t, selected, m.tail.logs[m.cursor], "the selected log should be kept")
+	}
+
+	assert.Equal(t, lines(monitor.logs), lines(m.tail.logs))
+}
+
+// collect runs the command and returns the messages of the given type.
+func collect[T tea.Msg](cmd tea.Cmd) []T {
+	if cmd == nil {
+		return nil
+	}
+
+	var msgs []T
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			msgs = append(msgs, collect[T](c)...)
+		}
+	case T:
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func TestModel_StructuredLogs(t *testing.T) {
+	now := time.Now()
+	monitor := &fakeMonitor{logs: []resource.Log{
+		resource.NewLog(now.Add(-2*time.Second), "plain line", nil),
+		resource.NewLog(now.Add(-time.Second), `{"message":"request failed","level":"error","request_id":"abc"}`, nil),
+	}}
+
+	state := newTestState(monitor)
+	m := Journal(state, &testhelpers.MockResource{}, 80, 5)
+	m, _ = m.Update(m.fetchLogs()())
+	require.Equal(t, 1, m.cursor, "the most recent log should be selected")
+
+	t.Run("only the message of structured logs is shown", func(t *testing.T) {
+		view := m.viewport.View()
+		assert.Contains(t, view, "request failed")
+		assert.NotContains(t, view, "request_id")
+	})
+
+	t.Run("fields are shown as columns", func(t *testing.T) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+		require.True(t, m.Editing())
+		assert.Contains(t, m.columnInput.Placeholder, "level, message, request_id")
+
+		m.columnInput.SetValue("request_id, level")
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, []string{"request_id", "level"}, m.columns)
+		assert.Contains(t, m.viewport.View(), "abc        error request failed")
+	})
+
+	t.Run("the selected log is expanded", func(t *testing.T) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+		assert.Equal(t, 0, m.cursor)
+
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m.SetDimensions(80, 20)
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.True(t, m.Expanded())
+		// the keys are highlighted separately from their values.
+		assert.Contains(t, m.detail.View(), `"request_id"`)
+		assert.Contains(t, m.detail.View(), `"abc"`)
+
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.False(t, m.Expanded())
+	})
+}
@@ -0,0 +1,89 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+// queryLogs queries the logs of each resource at the same time, and interleaves them by timestamp.
+// The logs of the resources that failed are missing, and their errors are joined.
+//
+// When a resource has more logs than the limit of a backward query, the older logs of the other
+// resources are dropped, as the ones of the resource before its oldest log are missing.
+// hasMore is then true, and the next page starts at the oldest of the merged logs.
+func queryLogs(ctx context.Context, monitor resource.Monitorer, resources []resource.Resource, query resource.LogsQuery) (logs []resource.Log, hasMore bool, err error) {
+	pages := make([][]resource.Log, len(resources))
+	errs := make([]error, len(resources))
+
+	var wg sync.WaitGroup
+	for i, r := range resources {
+		i, r := i, r
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			page, err := monitor.Logs(ctx, r, query)
+			if err != nil && len(resources) > 1 {
+				err = fmt.Errorf("%s: %w", describe(r), err)
+			}
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for j := range page {
+				page[j].ResourceID = r.Metadata().ID
+			}
+			pages[i] = page
+		}()
+	}
+	wg.Wait()
+
+	logs, hasMore = mergePages(pages, query)
+	return logs, hasMore, errors.Join(errs...)
+}
+
+// mergePages interleaves the pages of logs of several resources by timestamp.
+func mergePages(pages [][]resource.Log, query resource.LogsQuery) ([]resource.Log, bool) {
+	var (
+		cutoff  time.Time
+		hasMore bool
+		total   int
+	)
+	for _, page := range pages {
+		total += len(page)
+		if query.Limit <= 0 || query.Direction != resource.LogsBackward || len(page) < query.Limit {
+			continue
+		}
+		hasMore = true
+		if oldest := page[0].Timestamp; oldest.After(cutoff) {
+			cutoff = oldest
+		}
+	}
+
+	logs := make([]resource.Log, 0, total)
+	for _, page := range pages {
+		for _, l := range page {
+			if !l.Timestamp.Before(cutoff) {
+				logs = append(logs, l)
+			}
+		}
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Timestamp.Before(logs[j].Timestamp)
+	})
+	return logs, hasMore
+}
+
+// describe returns the type and the name of a resource, such as "function foo".
+func describe(r resource.Resource) string {
+	metadata := r.Metadata()
+	return strings.ToLower(metadata.Type.String()) + " " + metadata.Name
+}
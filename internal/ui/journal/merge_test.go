@@ -0,0 +1,132 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePages(t *testing.T) {
+	now := time.Now()
+	at := func(seconds int, line string) resource.Log {
+		return resource.Log{Timestamp: now.Add(time.Duration(seconds) * time.Second), Line: line}
+	}
+
+	t.Run("logs are interleaved by timestamp", func(t *testing.T) {
+		logs, hasMore := mergePages([][]resource.Log{
+			{at(1, "a1"), at(3, "a3")},
+			{at(2, "b2"), at(4, "b4")},
+		}, resource.LogsQuery{Limit: 10, Direction: resource.LogsBackward})
+
+		assert.False(t, hasMore)
+		assert.Equal(t, []resource.Log{at(1, "a1"), at(2, "b2"), at(3, "a3"), at(4, "b4")}, logs)
+	})
+
+	t.Run("logs older than a full page are dropped", func(t *testing.T) {
+		logs, hasMore := mergePages([][]resource.Log{
+			{at(1, "a1"), at(2, "a2"), at(5, "a5")},
+			// this page is full, so the logs of the resource before 3 are missing.
+			{at(3, "b3"), at(4, "b4")},
+		}, resource.LogsQuery{Limit: 2, Direction: resource.LogsBackward})
+
+		assert.True(t, hasMore)
+		assert.Equal(t, []resource.Log{at(3, "b3"), at(4, "b4"), at(5, "a5")}, logs)
+	})
+
+	t.Run("forward queries are not cut", func(t *testing.T) {
+		logs, hasMore := mergePages([][]resource.Log{
+			{at(1, "a1"), at(2, "a2")},
+			{at(3, "b3")},
+		}, resource.LogsQuery{Limit: 2, Direction: resource.LogsForward})
+
+		assert.False(t, hasMore)
+		assert.Len(t, logs, 3)
+	})
+}
+
+type failingMonitor struct {
+	fakeMonitor
+	failing string
+}
+
+func (f *failingMonitor) Logs(ctx context.Context, r resource.Resource, query resource.LogsQuery) ([]resource.Log, error) {
+	if r.Metadata().ID == f.failing {
+		return nil, errors.New("unavailable")
+	}
+	return f.fakeMonitor.Logs(ctx, r, query)
+}
+
+func TestQueryLogs(t *testing.T) {
+	now := time.Now()
+	foo := mockLogResource("foo-id", "foo", true)
+	bar := mockLogResource("bar-id", "bar", true)
+
+	monitor := &failingMonitor{
+		fakeMonitor: fakeMonitor{perResource: map[string][]resource.Log{
+			"foo-id": {{Timestamp: now.Add(-time.Second), Line: "from foo"}},
+		}},
+		failing: "bar-id",
+	}
+
+	logs, _, err := queryLogs(context.Background(), monitor, []resource.Resource{foo, bar}, resource.LogsQuery{Start: now.Add(-time.Hour), End: now})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bar: unavailable", "the error should name the resource")
+
+	require.Len(t, logs, 1, "the logs of the other resources should be kept")
+	assert.Equal(t, "foo-id", logs[0].ResourceID)
+}
+
+func mockLogResource(id, name string, canViewLogs bool) *testhelpers.MockResource {
+	return &testhelpers.MockResource{
+		MetadataValue:        resource.Metadata{ID: id, Name: name, Type: resource.TypeFunction},
+		CockpitMetadataValue: resource.CockpitMetadata{CanViewLogs: canViewLogs},
+	}
+}
+
+func TestModel_Merged(t *testing.T) {
+	now := time.Now()
+	monitor := &fakeMonitor{perResource: map[string][]resource.Log{
+		"foo-id": {{Timestamp: now.Add(-3 * time.Second), Line: "first"}, {Timestamp: now.Add(-time.Second), Line: "third"}},
+		"bar-id": {{Timestamp: now.Add(-2 * time.Second), Line: "second"}},
+	}}
+
+	state := newTestState(monitor)
+	m := Merged(state, []resource.Resource{
+		mockLogResource("foo-id", "foo", true),
+		mockLogResource("bar-id", "bar", true),
+		mockLogResource("baz-id", "baz", false),
+	}, 80, 5)
+	m, _ = m.Update(m.fetchLogs()())
+	require.Equal(t, StatusLoaded, m.status)
+
+	t.Run("lines are interleaved and prefixed with their resource", func(t *testing.T) {
+		view := m.viewport.View()
+		assert.Regexp(t, `foo +.*first[\s\S]*bar +.*second[\s\S]*foo +.*third`, view)
+	})
+
+	t.Run("resources without logs are skipped with a note", func(t *testing.T) {
+		assert.Len(t, monitor.queries, 2)
+		assert.Contains(t, m.View(), "Skipped 1 resources without logs: baz")
+	})
+
+	t.Run("the same line of two resources is kept", func(t *testing.T) {
+		msg := LogsMsg{
+			ResourceID:  m.id,
+			generation:  m.generation,
+			poll:        m.poll,
+			incremental: true,
+			Logs: []resource.Log{
+				{Timestamp: now, Line: "same", ResourceID: "foo-id"},
+				{Timestamp: now, Line: "same", ResourceID: "bar-id"},
+			},
+		}
+		m, _ = m.Update(msg)
+		assert.Len(t, m.tail.logs, 5)
+	})
+}
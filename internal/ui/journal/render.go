@@ -0,0 +1,191 @@
+package journal
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/chroma/v2/quick"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	dateFormat = "2006-01-02 15:04:05"
+
+	// maxColumnWidth is the maximum width of a column, the longer values are truncated.
+	maxColumnWidth = 30
+	// maxSuggestedFields is the maximum number of fields suggested when picking the columns.
+	maxSuggestedFields = 8
+	// maxSourceWidth is the maximum width of the name of a resource, when the logs are merged.
+	maxSourceWidth = 20
+	// maxSkippedNames is the maximum number of skipped resources named in the header.
+	maxSkippedNames = 3
+)
+
+// source is the prefix of the lines of a resource, when the logs of several resources are merged.
+type source struct {
+	// resource is the name of the resource, and name the one shown, which may be truncated.
+	resource string
+	name     string
+	style    lipgloss.Style
+}
+
+// sourceStyle returns the style of the i-th resource, cycling through the palette.
+func (m Model) sourceStyle(i int) lipgloss.Style {
+	palette := m.state.Styles.LogSources
+	if len(palette) == 0 {
+		return lipgloss.NewStyle()
+	}
+	return palette[i%len(palette)]
+}
+
+// skippedNote lists the resources whose logs cannot be viewed.
+func (m Model) skippedNote() string {
+	names := make([]string, 0, maxSkippedNames)
+	for _, r := range m.skipped {
+		if len(names) == maxSkippedNames {
+			names = append(names, "...")
+			break
+		}
+		names = append(names, r.Metadata().Name)
+	}
+
+	note := fmt.Sprintf("Skipped %d resources without logs: %s", len(m.skipped), strings.Join(names, ", "))
+	if len(m.resources) == 0 {
+		note += " (none of the resources have logs)"
+	}
+	return note
+}
+
+// parseColumns parses a comma-separated list of fields.
+func parseColumns(s string) []string {
+	var columns []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// fieldNames returns the names of the fields and labels of the logs, sorted.
+func fieldNames(logs []resource.Log) []string {
+	seen := make(map[string]struct{})
+	for _, l := range logs {
+		for name := range l.Fields {
+			seen[name] = struct{}{}
+		}
+		for name := range l.Labels {
+			seen[name] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// columnWidths returns the width of each column, so that the values of the logs are aligned.
+func columnWidths(logs []resource.Log, columns []string) []int {
+	widths := make([]int, len(columns))
+	for i, name := range columns {
+		widths[i] = runewidth.StringWidth(name)
+		for _, l := range logs {
+			if v, ok := l.Field(name); ok {
+				widths[i] = max(widths[i], runewidth.StringWidth(v))
+			}
+		}
+		widths[i] = min(widths[i], maxColumnWidth)
+	}
+	return widths
+}
+
+// levelStyle returns the style of a log, depending on its level.
+func (m Model) levelStyle(l resource.Log) lipgloss.Style {
+	switch l.Level() {
+	case resource.LogLevelDebug:
+		return m.state.Styles.LogDebug
+	case resource.LogLevelWarning:
+		return m.state.Styles.LogWarning
+	case resource.LogLevelError:
+		return m.state.Styles.LogError
+	case resource.LogLevelInfo, resource.LogLevelUnknown:
+	}
+	return lipgloss.NewStyle()
+}
+
+// renderLog renders a log, wrapped to the width of the viewport.
+// The lines of structured logs only show their message, after the selected columns.
+func (m Model) renderLog(l resource.Log, selected bool) string {
+	var b strings.Builder
+	b.WriteString(l.Timestamp.Format(dateFormat))
+	b.WriteString(": ")
+	for i, name := range m.columns {
+		v, _ := l.Field(name)
+		b.WriteString(runewidth.FillRight(runewidth.Truncate(v, m.columnWidths[i], "…"), m.columnWidths[i]))
+		b.WriteRune(' ')
+	}
+	b.WriteString(l.Message())
+
+	style := m.levelStyle(l)
+	if selected {
+		style = m.state.Styles.LogSelected.Copy().Inherit(style)
+	}
+
+	src, ok := m.sources[l.ResourceID]
+	if !ok {
+		return style.Render(runewidth.Wrap(b.String(), m.viewport.Width))
+	}
+
+	// the name of the resource is colored on its first line only, the lines are wrapped after it.
+	prefix := runewidth.FillRight(src.name, m.sourceWidth) + " "
+	width := max(m.viewport.Width-runewidth.StringWidth(prefix), 1)
+	lines := strings.Split(runewidth.Wrap(b.String(), width), "\n")
+	indent := strings.Repeat(" ", runewidth.StringWidth(prefix))
+	for i, line := range lines {
+		if i == 0 {
+			lines[i] = src.style.Render(prefix) + style.Render(line)
+			continue
+		}
+		lines[i] = indent + style.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prettyLog is the expanded view of a log.
+type prettyLog struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// Line is the JSON object of a structured log, or a string otherwise.
+	Line json.RawMessage `json:"line"`
+}
+
+// renderPrettyLog renders a log as pretty-printed JSON, with syntax highlighting.
+func (m Model) renderPrettyLog(l resource.Log) string {
+	pretty := prettyLog{Timestamp: l.Timestamp, Labels: l.Labels, Line: json.RawMessage(l.Line)}
+	if !l.Structured() {
+		// the error can be ignored, as a string can always be marshalled.
+		pretty.Line, _ = json.Marshal(l.Line)
+	}
+
+	b, err := json.MarshalIndent(pretty, "", "  ")
+	if err != nil {
+		m.state.Logger.Error("journal: failed to marshal log", "error", err.Error())
+		return l.Line
+	}
+
+	var w strings.Builder
+	err = quick.Highlight(&w, string(b), "json", "terminal16m", m.state.SyntaxHighlighterTheme)
+	if err != nil {
+		m.state.Logger.Error("journal: failed to highlight log", "error", err.Error())
+		return string(b)
+	}
+	return w.String()
+}
@@ -0,0 +1,25 @@
+package journal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColumns(t *testing.T) {
+	assert.Nil(t, parseColumns(""))
+	assert.Nil(t, parseColumns(" , "))
+	assert.Equal(t, []string{"level", "request_id"}, parseColumns("level, request_id,"))
+}
+
+func TestColumnWidths(t *testing.T) {
+	logs := []resource.Log{
+		resource.NewLog(time.Time{}, `{"level":"info","path":"/"}`, nil),
+		resource.NewLog(time.Time{}, `{"level":"warning","path":"/`+strings.Repeat("a", 2*maxColumnWidth)+`"}`, map[string]string{"stream": "stdout"}),
+	}
+
+	assert.Equal(t, []int{7, maxColumnWidth, 6, 7}, columnWidths(logs, []string{"level", "path", "stream", "missing"}))
+}
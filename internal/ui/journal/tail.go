@@ -0,0 +1,133 @@
+package journal
+
+import (
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+const (
+	// tailInterval is the interval at which the new logs are fetched when following them.
+	tailInterval = 2 * time.Second
+	// maxLogs is the maximum number of logs kept by the journal, the oldest ones are dropped.
+	maxLogs = 10000
+)
+
+// tail holds the logs of the journal, so that only the new ones are appended when following them.
+type tail struct {
+	logs []resource.Log
+
+	// atLast counts the lines at the timestamp of the last log, by key.
+	// The start of a Loki query is inclusive, so those lines are returned again by the next query.
+	atLast map[string]int
+}
+
+// logKey identifies the line of a log, among the logs with the same timestamp.
+// The logs of several resources can have the same line.
+func logKey(l resource.Log) string {
+	return l.ResourceID + "\x00" + l.Line
+}
+
+// reset replaces the logs.
+func (t *tail) reset(logs []resource.Log) {
+	t.logs = nil
+	t.atLast = nil
+	t.append(logs)
+}
+
+// since returns the timestamp of the last log, or the zero time if there are none.
+func (t *tail) since() time.Time {
+	if len(t.logs) == 0 {
+		return time.Time{}
+	}
+	return t.logs[len(t.logs)-1].Timestamp
+}
+
+// append adds the logs that were not seen yet, and returns them.
+// The logs must be sorted from the oldest to the most recent.
+func (t *tail) append(logs []resource.Log) []resource.Log {
+	last := t.since()
+	hasLast := len(t.logs) > 0
+
+	// the lines at the last timestamp that were already seen.
+	seen := make(map[string]int, len(t.atLast))
+	for line, count := range t.atLast {
+		seen[line] = count
+	}
+
+	added := make([]resource.Log, 0, len(logs))
+	for _, l := range logs {
+		if hasLast && l.Timestamp.Before(last) {
+			continue
+		}
+		if hasLast && l.Timestamp.Equal(last) && seen[logKey(l)] > 0 {
+			seen[logKey(l)]--
+			continue
+		}
+
+		if !hasLast || !l.Timestamp.Equal(last) {
+			last = l.Timestamp
+			hasLast = true
+			t.atLast = make(map[string]int)
+			// none of the lines at the new timestamp were seen before.
+			seen = nil
+		}
+		t.atLast[logKey(l)]++
+		added = append(added, l)
+	}
+
+	t.logs = append(t.logs, added...)
+	return added
+}
+
+// oldest returns the timestamp of the first log, or the zero time if there are none.
+func (t *tail) oldest() time.Time {
+	if len(t.logs) == 0 {
+		return time.Time{}
+	}
+	return t.logs[0].Timestamp
+}
+
+// prepend adds the older logs that were not seen yet, and returns them.
+// The end of the query of the older logs may be inclusive, so the lines at the
+// timestamp of the first log can be returned again.
+// The logs must be sorted from the oldest to the most recent.
+func (t *tail) prepend(logs []resource.Log) []resource.Log {
+	if len(t.logs) == 0 {
+		t.reset(logs)
+		return t.logs
+	}
+
+	first := t.oldest()
+	seen := make(map[string]int)
+	for _, l := range t.logs {
+		if !l.Timestamp.Equal(first) {
+			break
+		}
+		seen[logKey(l)]++
+	}
+
+	added := make([]resource.Log, 0, len(logs))
+	for _, l := range logs {
+		if l.Timestamp.After(first) {
+			continue
+		}
+		if l.Timestamp.Equal(first) && seen[logKey(l)] > 0 {
+			seen[logKey(l)]--
+			continue
+		}
+		added = append(added, l)
+	}
+
+	t.logs = append(added, t.logs...)
+	return added
+}
+
+// trim drops the oldest logs above maxLogs, and returns true if any log was dropped.
+func (t *tail) trim() bool {
+	if len(t.logs) <= maxLogs {
+		return false
+	}
+	t.logs = t.logs[len(t.logs)-maxLogs:]
+	return true
+}
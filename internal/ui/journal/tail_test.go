@@ -0,0 +1,82 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func lines(logs []resource.Log) []string {
+	lines := make([]string, 0, len(logs))
+	for _, l := range logs {
+		lines = append(lines, l.Line)
+	}
+	return lines
+}
+
+func TestTail_Append(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int, line string) resource.Log {
+		return resource.Log{Timestamp: now.Add(time.Duration(seconds) * time.Second), Line: line}
+	}
+
+	var tl tail
+	tl.reset([]resource.Log{at(0, "a"), at(1, "b"), at(1, "b")})
+	assert.Equal(t, now.Add(time.Second), tl.since())
+
+	t.Run("lines at the start of the query are not duplicated", func(t *testing.T) {
+		added := tl.append([]resource.Log{at(1, "b"), at(1, "b"), at(1, "c"), at(2, "d")})
+		assert.Equal(t, []string{"c", "d"}, lines(added))
+	})
+
+	t.Run("identical lines at a new timestamp are kept", func(t *testing.T) {
+		added := tl.append([]resource.Log{at(2, "d"), at(3, "e"), at(3, "e")})
+		assert.Equal(t, []string{"e", "e"}, lines(added))
+	})
+
+	t.Run("older lines are ignored", func(t *testing.T) {
+		added := tl.append([]resource.Log{at(0, "a"), at(3, "e"), at(3, "e")})
+		assert.Empty(t, added)
+	})
+
+	assert.Equal(t, []string{"a", "b", "b", "c", "d", "e", "e"}, lines(tl.logs))
+}
+
+func TestTail_Trim(t *testing.T) {
+	var tl tail
+	logs := make([]resource.Log, 0, maxLogs+1)
+	for i := 0; i <= maxLogs; i++ {
+		logs = append(logs, resource.Log{Timestamp: time.Unix(int64(i), 0), Line: "line"})
+	}
+	tl.reset(logs)
+
+	assert.True(t, tl.trim())
+	assert.Len(t, tl.logs, maxLogs)
+	assert.Equal(t, time.Unix(1, 0), tl.logs[0].Timestamp)
+	assert.False(t, tl.trim())
+}
+
+func TestTail_Prepend(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int, line string) resource.Log {
+		return resource.Log{Timestamp: now.Add(time.Duration(seconds) * time.Second), Line: line}
+	}
+
+	var tl tail
+	tl.reset([]resource.Log{at(2, "c"), at(2, "d"), at(3, "e")})
+
+	t.Run("lines at the end of the query are not duplicated", func(t *testing.T) {
+		added := tl.prepend([]resource.Log{at(0, "a"), at(1, "b"), at(2, "c"), at(2, "c2"), at(2, "d")})
+		assert.Equal(t, []string{"a", "b", "c2"}, lines(added))
+	})
+
+	t.Run("more recent lines are ignored", func(t *testing.T) {
+		added := tl.prepend([]resource.Log{at(3, "e"), at(4, "f")})
+		assert.Empty(t, added)
+	})
+
+	assert.Equal(t, []string{"a", "b", "c2", "c", "d", "e"}, lines(tl.logs))
+	assert.Equal(t, now, tl.oldest())
+}
@@ -0,0 +1,99 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+const (
+	// customRangeLayout is the layout of the dates of a custom time range.
+	customRangeLayout = "2006-01-02 15:04"
+	// customRangeSeparator separates the start and the end of a custom time range.
+	customRangeSeparator = " to "
+)
+
+// timeRange is the time range of the logs.
+// It is either relative to now, or an absolute range.
+type timeRange struct {
+	// last is the duration of a relative time range.
+	last time.Duration
+	// start and end are the bounds of an absolute time range.
+	start time.Time
+	end   time.Time
+}
+
+// nolint:gochecknoglobals
+var (
+	presets = []timeRange{
+		{last: 15 * time.Minute},
+		{last: time.Hour},
+		{last: 6 * time.Hour},
+		{last: 24 * time.Hour},
+		{last: 7 * 24 * time.Hour},
+	}
+	defaultPreset = 3
+)
+
+// relative returns true if the range moves with time.
+func (tr timeRange) relative() bool {
+	return tr.last > 0
+}
+
+// query returns the query for the logs of the time range.
+func (tr timeRange) query(now time.Time) resource.LogsQuery {
+	if tr.relative() {
+		return resource.LogsQuery{Start: now.Add(-tr.last), End: now}
+	}
+	return resource.LogsQuery{Start: tr.start, End: tr.end}
+}
+
+func (tr timeRange) String() string {
+	if !tr.relative() {
+		return tr.start.Format(customRangeLayout) + " → " + tr.end.Format(customRangeLayout)
+	}
+
+	switch {
+	case tr.last > 24*time.Hour && tr.last%(24*time.Hour) == 0:
+		return fmt.Sprintf("last %dd", tr.last/(24*time.Hour))
+	case tr.last%time.Hour == 0:
+		return fmt.Sprintf("last %dh", tr.last/time.Hour)
+	default:
+		return fmt.Sprintf("last %dm", tr.last/time.Minute)
+	}
+}
+
+// nextPreset returns the index of the preset after the time range.
+func nextPreset(current int) int {
+	return (current + 1) % len(presets)
+}
+
+var errInvalidRange = errors.New("the start of the range must be before its end")
+
+// parseTimeRange parses an absolute time range, such as "2024-01-02 15:04 to 2024-01-02 18:00".
+// The end is optional, and defaults to now.
+func parseTimeRange(s string, now time.Time, loc *time.Location) (timeRange, error) {
+	from, to, hasEnd := strings.Cut(strings.TrimSpace(s), customRangeSeparator)
+
+	start, err := time.ParseInLocation(customRangeLayout, strings.TrimSpace(from), loc)
+	if err != nil {
+		return timeRange{}, fmt.Errorf("invalid start, expected %q: %w", customRangeLayout, err)
+	}
+
+	end := now
+	if hasEnd {
+		end, err = time.ParseInLocation(customRangeLayout, strings.TrimSpace(to), loc)
+		if err != nil {
+			return timeRange{}, fmt.Errorf("invalid end, expected %q: %w", customRangeLayout, err)
+		}
+	}
+
+	if !start.Before(end) {
+		return timeRange{}, errInvalidRange
+	}
+
+	return timeRange{start: start, end: end}, nil
+}
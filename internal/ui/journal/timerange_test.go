@@ -0,0 +1,61 @@
+package journal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	now := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    timeRange
+		wantErr bool
+	}{
+		{
+			name:  "start and end",
+			value: "2024-01-01 08:30 to 2024-01-01 09:00",
+			want: timeRange{
+				start: time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC),
+				end:   time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "end defaults to now",
+			value: " 2024-01-01 08:30 ",
+			want: timeRange{
+				start: time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC),
+				end:   now,
+			},
+		},
+		{name: "start after end", value: "2024-01-01 09:00 to 2024-01-01 08:30", wantErr: true},
+		{name: "invalid start", value: "yesterday", wantErr: true},
+		{name: "invalid end", value: "2024-01-01 08:30 to later", wantErr: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseTimeRange(test.value, now, time.UTC)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestTimeRange_String(t *testing.T) {
+	labels := make([]string, 0, len(presets))
+	for _, preset := range presets {
+		labels = append(labels, preset.String())
+	}
+	assert.Equal(t, []string{"last 15m", "last 1h", "last 6h", "last 24h", "last 7d"}, labels)
+}
@@ -30,6 +30,10 @@ func DefaultKeyMap() KeyMap {
 				key.WithKeys("l"),
 				key.WithHelp("l", "logs"),
 			),
+			Metrics: key.NewBinding(
+				key.WithKeys("m"),
+				key.WithHelp("m", "metrics"),
+			),
 			Delete: key.NewBinding(
 				key.WithKeys("x"),
 				key.WithHelp("x", "delete"),
@@ -42,6 +46,51 @@ func DefaultKeyMap() KeyMap {
 				key.WithKeys("g"),
 				key.WithHelp("g", "view ids"),
 			),
+			Discovery: key.NewBinding(
+				key.WithKeys("s"),
+				key.WithHelp("s", "discovery status"),
+			),
+			History: key.NewBinding(
+				key.WithKeys("h"),
+				key.WithHelp("h", "history"),
+			),
+			Browse: key.NewBinding(
+				// "b" already scrolls a page up in the table.
+				key.WithKeys("B"),
+				key.WithHelp("B", "browse objects"),
+			),
+			Topology: key.NewBinding(
+				key.WithKeys("n"),
+				key.WithHelp("n", "network topology"),
+			),
+			Feed: key.NewBinding(
+				key.WithKeys("c"),
+				key.WithHelp("c", "changes"),
+			),
+			ToggleTreeView: key.NewBinding(
+				key.WithKeys("v"),
+				key.WithHelp("v", "tree view"),
+			),
+			ToggleNode: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "expand/collapse"),
+			),
+			SortColumn: key.NewBinding(
+				key.WithKeys("o"),
+				key.WithHelp("o", "sort by next column"),
+			),
+			SortOrder: key.NewBinding(
+				key.WithKeys("O"),
+				key.WithHelp("O", "reverse sort"),
+			),
+			Mark: key.NewBinding(
+				key.WithKeys(" "),
+				key.WithHelp("space", "select"),
+			),
+			MarkAll: key.NewBinding(
+				key.WithKeys("a"),
+				key.WithHelp("a", "select all"),
+			),
 		},
 		ConfirmKeyMap: ConfirmKeyMap{
 			RootKeyMap: defaultRootKeyMap,
@@ -59,6 +108,117 @@ func DefaultKeyMap() KeyMap {
 			),
 			ListKeyMap: list.DefaultKeyMap(),
 		},
+		JournalKeyMap: JournalKeyMap{
+			RootKeyMap: defaultRootKeyMap,
+			TimeRange: key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", "next time range"),
+			),
+			CustomTimeRange: key.NewBinding(
+				key.WithKeys("R"),
+				key.WithHelp("R", "custom time range"),
+			),
+			Filter: key.NewBinding(
+				key.WithKeys("/"),
+				key.WithHelp("/", "filter"),
+			),
+			Follow: key.NewBinding(
+				key.WithKeys("f"),
+				key.WithHelp("f", "follow/pause"),
+			),
+			Columns: key.NewBinding(
+				key.WithKeys("c"),
+				key.WithHelp("c", "columns"),
+			),
+			Expand: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "expand/collapse"),
+			),
+			PreviousLog: key.NewBinding(
+				key.WithKeys("up", "k"),
+				key.WithHelp("↑/k", "previous log"),
+			),
+			NextLog: key.NewBinding(
+				key.WithKeys("down", "j"),
+				key.WithHelp("↓/j", "next log"),
+			),
+			Export: key.NewBinding(
+				key.WithKeys("e"),
+				key.WithHelp("e", "export"),
+			),
+		},
+		MetricsKeyMap: MetricsKeyMap{
+			RootKeyMap: defaultRootKeyMap,
+			Window: key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", "next time window"),
+			),
+		},
+		BulkKeyMap: BulkKeyMap{
+			RootKeyMap: defaultRootKeyMap,
+			Run: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "to confirm"),
+			),
+			ScrollUp: key.NewBinding(
+				key.WithKeys("up", "k"),
+				key.WithHelp("↑/k", "scroll up"),
+			),
+			ScrollDown: key.NewBinding(
+				key.WithKeys("down", "j"),
+				key.WithHelp("↓/j", "scroll down"),
+			),
+		},
+		BrowserKeyMap: BrowserKeyMap{
+			RootKeyMap: defaultRootKeyMap,
+			PreviousObject: key.NewBinding(
+				key.WithKeys("up", "k"),
+				key.WithHelp("↑/k", "previous object"),
+			),
+			NextObject: key.NewBinding(
+				key.WithKeys("down", "j"),
+				key.WithHelp("↓/j", "next object"),
+			),
+			OpenPrefix: key.NewBinding(
+				key.WithKeys("enter", "right"),
+				key.WithHelp("enter", "open folder"),
+			),
+			ParentPrefix: key.NewBinding(
+				key.WithKeys("backspace", "left"),
+				key.WithHelp("backspace", "parent folder"),
+			),
+			Download: key.NewBinding(
+				key.WithKeys("d"),
+				key.WithHelp("d", "download"),
+			),
+			DeleteObject: key.NewBinding(
+				key.WithKeys("x"),
+				key.WithHelp("x", "delete"),
+			),
+			PresignURL: key.NewBinding(
+				key.WithKeys("u"),
+				key.WithHelp("u", "presigned url"),
+			),
+			Reload: key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", "reload"),
+			),
+		},
+		HistoryKeyMap: HistoryKeyMap{
+			RootKeyMap: defaultRootKeyMap,
+			Up: key.NewBinding(
+				key.WithKeys("up", "k"),
+				key.WithHelp("↑/k", "newer"),
+			),
+			Down: key.NewBinding(
+				key.WithKeys("down", "j"),
+				key.WithHelp("↓/j", "older"),
+			),
+			Compare: key.NewBinding(
+				key.WithKeys(" "),
+				key.WithHelp("space", "compare with selected"),
+			),
+		},
 	}
 }
 
@@ -67,6 +227,11 @@ type KeyMap struct {
 	TableKeyMap
 	ConfirmKeyMap
 	ActionsKeyMap
+	HistoryKeyMap
+	JournalKeyMap
+	BulkKeyMap
+	MetricsKeyMap
+	BrowserKeyMap
 }
 
 func (m KeyMap) Get(focused Focused) help.KeyMap {
@@ -77,6 +242,16 @@ func (m KeyMap) Get(focused Focused) help.KeyMap {
 		return m.ConfirmKeyMap
 	case ActionsFocused:
 		return m.ActionsKeyMap
+	case HistoryFocused:
+		return m.HistoryKeyMap
+	case JournalFocused:
+		return m.JournalKeyMap
+	case BulkFocused:
+		return m.BulkKeyMap
+	case MetricsFocused:
+		return m.MetricsKeyMap
+	case BrowserFocused:
+		return m.BrowserKeyMap
 	default:
 		return m.RootKeyMap
 	}
@@ -100,9 +275,23 @@ type TableKeyMap struct {
 	Search        key.Binding
 	Describe      key.Binding
 	Logs          key.Binding
+	Metrics       key.Binding
 	Delete        key.Binding
 	Actions       key.Binding
 	ToggleAltView key.Binding
+	Discovery     key.Binding
+	History       key.Binding
+	Browse        key.Binding
+	Topology      key.Binding
+	Feed          key.Binding
+	SortColumn    key.Binding
+	SortOrder     key.Binding
+
+	ToggleTreeView key.Binding
+	ToggleNode     key.Binding
+
+	Mark    key.Binding
+	MarkAll key.Binding
 }
 
 func (m TableKeyMap) ShortHelp() []key.Binding {
@@ -110,9 +299,20 @@ func (m TableKeyMap) ShortHelp() []key.Binding {
 		m.Search,
 		m.Describe,
 		m.Logs,
+		m.Metrics,
 		m.Delete,
 		m.Actions,
 		m.ToggleAltView,
+		m.ToggleTreeView,
+		m.Discovery,
+		m.History,
+		m.Browse,
+		m.Topology,
+		m.Feed,
+		m.SortColumn,
+		m.SortOrder,
+		m.Mark,
+		m.MarkAll,
 		m.Quit,
 	}
 }
@@ -151,3 +351,121 @@ func (m ActionsKeyMap) ShortHelp() []key.Binding {
 		m.Quit,
 	}
 }
+
+func (m ActionsKeyMap) FullHelp() [][]key.Binding {
+	return nil
+}
+
+type HistoryKeyMap struct {
+	RootKeyMap
+	Up      key.Binding
+	Down    key.Binding
+	Compare key.Binding
+}
+
+func (m HistoryKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.Up,
+		m.Down,
+		m.Compare,
+		m.Quit,
+	}
+}
+
+func (m HistoryKeyMap) FullHelp() [][]key.Binding {
+	return nil
+}
+
+type JournalKeyMap struct {
+	RootKeyMap
+	TimeRange       key.Binding
+	CustomTimeRange key.Binding
+	Filter          key.Binding
+	Follow          key.Binding
+	Columns         key.Binding
+	Expand          key.Binding
+	PreviousLog     key.Binding
+	NextLog         key.Binding
+	Export          key.Binding
+}
+
+func (m JournalKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.TimeRange,
+		m.CustomTimeRange,
+		m.Filter,
+		m.Follow,
+		m.Columns,
+		m.Expand,
+		m.Export,
+		m.Quit,
+	}
+}
+
+func (m JournalKeyMap) FullHelp() [][]key.Binding {
+	return nil
+}
+
+type BulkKeyMap struct {
+	RootKeyMap
+	Run        key.Binding
+	ScrollUp   key.Binding
+	ScrollDown key.Binding
+}
+
+func (m BulkKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.Run,
+		m.ScrollUp,
+		m.ScrollDown,
+		m.Quit,
+	}
+}
+
+func (m BulkKeyMap) FullHelp() [][]key.Binding {
+	return nil
+}
+
+type MetricsKeyMap struct {
+	RootKeyMap
+	Window key.Binding
+}
+
+func (m MetricsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.Window,
+		m.Quit,
+	}
+}
+
+func (m MetricsKeyMap) FullHelp() [][]key.Binding {
+	return nil
+}
+
+type BrowserKeyMap struct {
+	RootKeyMap
+	PreviousObject key.Binding
+	NextObject     key.Binding
+	OpenPrefix     key.Binding
+	ParentPrefix   key.Binding
+	Download       key.Binding
+	DeleteObject   key.Binding
+	PresignURL     key.Binding
+	Reload         key.Binding
+}
+
+func (m BrowserKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		m.OpenPrefix,
+		m.ParentPrefix,
+		m.Download,
+		m.DeleteObject,
+		m.PresignURL,
+		m.Reload,
+		m.Quit,
+	}
+}
+
+func (m BrowserKeyMap) FullHelp() [][]key.Binding {
+	return nil
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+// brailleDots are the bits of the dots of a braille character, by column and row from the top.
+//
+// nolint:gochecknoglobals
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// brailleBlank is the braille character without any dot.
+const brailleBlank = 0x2800
+
+// brailleChart plots the samples between start and end as a line, on width x height characters.
+// Each braille character is a grid of 2x4 dots. The values are scaled from 0 to hi.
+func brailleChart(samples []resource.Sample, start, end time.Time, hi float64, width, height int) []string {
+	cols, rows := width*2, height*4
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = make([]rune, width)
+		for j := range grid[i] {
+			grid[i][j] = brailleBlank
+		}
+	}
+
+	// set draws the dot at column x and row y, from the bottom.
+	set := func(x, y int) {
+		top := rows - 1 - max(0, min(y, rows-1))
+		grid[top/4][x/2] |= brailleDots[x%2][top%4]
+	}
+	// fill draws the dots between two rows, so that the chart looks like a line.
+	fill := func(x, from, to int) {
+		for y := min(from, to); y <= max(from, to); y++ {
+			set(x, y)
+		}
+	}
+
+	span := end.Sub(start)
+	if cols == 0 || rows == 0 || span <= 0 || hi <= 0 {
+		return render(grid)
+	}
+
+	prevX, prevY := -1, 0
+	for _, s := range samples {
+		if s.Timestamp.Before(start) || s.Timestamp.After(end) {
+			continue
+		}
+		x := int(float64(s.Timestamp.Sub(start))/float64(span)*float64(cols-1) + 0.5)
+		y := int(s.Value/hi*float64(rows-1) + 0.5)
+
+		if prevX < 0 || x <= prevX {
+			set(x, y)
+			prevX, prevY = x, y
+			continue
+		}
+
+		// the samples are usually sparser than the columns, so they are joined by a line.
+		last := prevY
+		for cx := prevX + 1; cx <= x; cx++ {
+			cy := prevY + (y-prevY)*(cx-prevX)/(x-prevX)
+			fill(cx, last, cy)
+			last = cy
+		}
+		prevX, prevY = x, y
+	}
+
+	return render(grid)
+}
+
+func render(grid [][]rune) []string {
+	lines := make([]string, 0, len(grid))
+	for _, row := range grid {
+		lines = append(lines, string(row))
+	}
+	return lines
+}
+
+// maxValue returns the maximum value of the samples, or 0 if there are none.
+func maxValue(samples []resource.Sample) float64 {
+	var hi float64
+	for _, s := range samples {
+		hi = max(hi, s.Value)
+	}
+	return hi
+}
+
+// formatValue formats a value with its unit, such as "1.5 MiB" or "12.3%".
+func formatValue(v float64, unit string) string {
+	switch unit {
+	case "bytes":
+		units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+		i := 0
+		for v >= 1024 && i < len(units)-1 {
+			v /= 1024
+			i++
+		}
+		return formatNumber(v) + " " + units[i]
+	case "%":
+		return formatNumber(v) + "%"
+	default:
+		return formatNumber(v) + " " + unit
+	}
+}
+
+// formatNumber formats a number with three significant digits at most.
+func formatNumber(v float64) string {
+	switch {
+	case v >= 100:
+		return fmt.Sprintf("%.0f", v)
+	case v >= 10:
+		return fmt.Sprintf("%.1f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
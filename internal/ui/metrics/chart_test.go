@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrailleChart(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	t.Run("without samples", func(t *testing.T) {
+		assert.Equal(t, []string{"⠀⠀", "⠀⠀"}, brailleChart(nil, start, end, 1, 2, 2))
+	})
+
+	t.Run("flat line at the maximum", func(t *testing.T) {
+		samples := []resource.Sample{{Timestamp: start, Value: 1}, {Timestamp: end, Value: 1}}
+		assert.Equal(t, []string{"⠉⠉"}, brailleChart(samples, start, end, 1, 2, 1))
+	})
+
+	t.Run("the samples are joined by a line", func(t *testing.T) {
+		samples := []resource.Sample{{Timestamp: start, Value: 0}, {Timestamp: end, Value: 1}}
+		assert.Equal(t, []string{"⣸"}, brailleChart(samples, start, end, 1, 1, 1))
+	})
+
+	t.Run("the samples outside of the range are ignored", func(t *testing.T) {
+		samples := []resource.Sample{{Timestamp: start.Add(-time.Minute), Value: 1}, {Timestamp: end.Add(time.Minute), Value: 1}}
+		assert.Equal(t, []string{"⠀"}, brailleChart(samples, start, end, 1, 1, 1))
+	})
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "512 B", formatValue(512, "bytes"))
+	assert.Equal(t, "1.50 MiB", formatValue(1.5*1024*1024, "bytes"))
+	assert.Equal(t, "12.3%", formatValue(12.34, "%"))
+	assert.Equal(t, "0.25 cores", formatValue(0.25, "cores"))
+	assert.Equal(t, "150 req/s", formatValue(150.4, "req/s"))
+}
@@ -0,0 +1,285 @@
+package metrics
+
+// A component to view the metrics of a resource as charts.
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/ui"
+)
+
+type Status int
+
+const (
+	// StatusLoading indicates that the metrics are being loaded.
+	StatusLoading Status = iota
+	// StatusLoaded indicates that the metrics have been loaded.
+	StatusLoaded
+)
+
+const (
+	// fetchTimeout is the maximum duration of a query.
+	fetchTimeout = 10 * time.Second
+	// refreshInterval is the interval at which the metrics are fetched again.
+	refreshInterval = 30 * time.Second
+	// panelsPerRow is the number of charts side by side.
+	panelsPerRow = 2
+)
+
+// nolint:gochecknoglobals
+var (
+	windows = []time.Duration{
+		15 * time.Minute,
+		time.Hour,
+		6 * time.Hour,
+		24 * time.Hour,
+		7 * 24 * time.Hour,
+	}
+	defaultWindow = 1
+
+	metricNames = map[resource.Metric]string{
+		resource.MetricCPU:         "CPU",
+		resource.MetricMemory:      "Memory",
+		resource.MetricRequestRate: "Requests",
+		resource.MetricErrorRate:   "Errors",
+	}
+)
+
+func Metrics(state ui.ApplicationState, r resource.Resource, width, height int) Model {
+	return Model{
+		state:    state,
+		resource: r,
+		spinner:  spinner.New(spinner.WithSpinner(spinner.Line)),
+		status:   StatusLoading,
+		window:   defaultWindow,
+		width:    width,
+		height:   height,
+	}
+}
+
+type MetricsMsg struct {
+	Err        error
+	Series     []resource.Series
+	ResourceID string
+	// generation is used to ignore the metrics of a previous window.
+	generation int
+	// start and end are the bounds of the query.
+	start time.Time
+	end   time.Time
+}
+
+func fetchMetrics(state ui.ApplicationState, r resource.Resource, window, step time.Duration, generation int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+
+		end := time.Now()
+		start := end.Add(-window)
+
+		series, err := state.Monitor.Metrics(ctx, r, resource.MetricsQuery{Start: start, End: end, Step: step})
+		if err != nil {
+			state.Logger.Error("metrics: failed to get metrics", slog.String("error", err.Error()))
+		}
+		return MetricsMsg{Err: err, Series: series, ResourceID: r.Metadata().ID, generation: generation, start: start, end: end}
+	}
+}
+
+type refreshMsg struct {
+	generation int
+}
+
+func (m Model) fetch() tea.Cmd {
+	// a sample for each column of dots.
+	step := windows[m.window] / time.Duration(max(2*m.panelWidth(), 1))
+	return fetchMetrics(m.state, m.resource, windows[m.window], step, m.generation)
+}
+
+// Init initializes the metrics component.
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetch())
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, m.state.Keys.Window) {
+			m.window = (m.window + 1) % len(windows)
+			m.generation++
+			m.status = StatusLoading
+			m.errorMsg = ""
+			return m, tea.Batch(m.spinner.Tick, m.fetch())
+		}
+	case MetricsMsg:
+		if msg.ResourceID != m.resource.Metadata().ID || msg.generation != m.generation {
+			return m, nil
+		}
+
+		generation := m.generation
+		refresh := tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+			return refreshMsg{generation: generation}
+		})
+
+		if msg.Err != nil {
+			m.errorMsg = fmt.Sprintf("Error getting metrics: %s", msg.Err)
+			// keep the previous metrics, if any.
+			if m.status == StatusLoading {
+				m.status = StatusLoaded
+			}
+			return m, refresh
+		}
+
+		m.errorMsg = ""
+		m.status = StatusLoaded
+		m.series = msg.Series
+		m.start, m.end = msg.start, msg.end
+		return m, refresh
+	case refreshMsg:
+		if msg.generation != m.generation {
+			return m, nil
+		}
+		return m, m.fetch()
+	case spinner.TickMsg:
+		if m.status != StatusLoading {
+			return m, nil
+		}
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// panelWidth is the width of a chart.
+func (m Model) panelWidth() int {
+	return max((m.width-(panelsPerRow-1))/panelsPerRow, 1)
+}
+
+func (m Model) viewPanel(s resource.Series, width, height int) string {
+	title := metricNames[s.Metric]
+	if title == "" {
+		title = string(s.Metric)
+	}
+
+	if len(s.Samples) == 0 {
+		body := lipgloss.Place(width, max(height-1, 1), lipgloss.Center, lipgloss.Center, "No data")
+		return lipgloss.JoinVertical(lipgloss.Left, m.state.Styles.Title.Render(title), body)
+	}
+
+	last := s.Samples[len(s.Samples)-1].Value
+	hi := maxValue(s.Samples)
+	title += fmt.Sprintf(": %s, max %s", formatValue(last, s.Unit), formatValue(hi, s.Unit))
+
+	chart := brailleChart(s.Samples, m.start, m.end, hi, width, max(height-1, 1))
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.state.Styles.Title.Render(title),
+		m.state.Styles.Chart.Render(strings.Join(chart, "\n")),
+	)
+}
+
+func (m Model) viewCharts() string {
+	if len(m.series) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, "No metrics for this resource")
+	}
+
+	numRows := (len(m.series) + panelsPerRow - 1) / panelsPerRow
+	panelHeight := max(m.height/numRows, 2)
+	panelWidth := m.panelWidth()
+
+	rows := make([]string, 0, numRows)
+	for i := 0; i < len(m.series); i += panelsPerRow {
+		panels := make([]string, 0, 2*panelsPerRow-1)
+		for j := i; j < min(i+panelsPerRow, len(m.series)); j++ {
+			if j > i {
+				panels = append(panels, " ")
+			}
+			panel := m.viewPanel(m.series[j], panelWidth, panelHeight)
+			panels = append(panels, lipgloss.NewStyle().Width(panelWidth).Height(panelHeight).MaxHeight(panelHeight).Render(panel))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, panels...))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+func (m Model) View() string {
+	var body string
+
+	switch m.status {
+	case StatusLoading:
+		body = lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.spinner.View())
+	case StatusLoaded:
+		body = lipgloss.NewStyle().Height(m.height).MaxHeight(m.height).Render(m.viewCharts())
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.viewHeader(),
+		m.state.Styles.BaseBorder.Width(m.width).Render(body),
+	)
+}
+
+func (m Model) viewHeader() string {
+	metadata := m.resource.Metadata()
+	header := m.state.Styles.Title.Render("Metrics for " + strings.ToLower(metadata.Type.String()) + " " + metadata.Name)
+	header += m.state.Styles.Title.Copy().Bold(false).Render("(last " + formatWindow(windows[m.window]) + ")")
+	if m.errorMsg != "" {
+		header += "\n" + m.state.Styles.Error.Render(m.errorMsg)
+	}
+	return header
+}
+
+// formatWindow formats a window, such as "15m", "6h" or "7d".
+func formatWindow(d time.Duration) string {
+	switch {
+	case d > 24*time.Hour && d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+}
+
+func (m *Model) SetDimensions(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Model is the model for the metrics component.
+type Model struct {
+	// errorMsg is the error message to display.
+	errorMsg string
+	// state is the context.
+	state ui.ApplicationState
+	// resource is the resource to monitor.
+	resource resource.Resource
+
+	// status is the status of the metrics.
+	status Status
+	// spinner is the spinner to display while loading.
+	spinner spinner.Model
+
+	// window is the index of the time window of the charts.
+	window int
+	// generation is incremented each time the window changes, to ignore the previous queries.
+	generation int
+
+	// series are the metrics, and start and end the bounds of their query.
+	series []resource.Series
+	start  time.Time
+	end    time.Time
+
+	width  int
+	height int
+}
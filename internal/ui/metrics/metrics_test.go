@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMonitor returns a constant CPU usage, and no memory usage.
+type fakeMonitor struct {
+	queries []resource.MetricsQuery
+}
+
+func (f *fakeMonitor) Logs(context.Context, resource.Resource, resource.LogsQuery) ([]resource.Log, error) {
+	return nil, nil
+}
+
+func (f *fakeMonitor) Metrics(_ context.Context, _ resource.Resource, query resource.MetricsQuery) ([]resource.Series, error) {
+	f.queries = append(f.queries, query)
+	return []resource.Series{
+		{Metric: resource.MetricCPU, Unit: "cores", Samples: []resource.Sample{
+			{Timestamp: query.Start, Value: 0.5},
+			{Timestamp: query.End, Value: 0.5},
+		}},
+		{Metric: resource.MetricMemory, Unit: "bytes"},
+	}, nil
+}
+
+func TestModel(t *testing.T) {
+	monitor := &fakeMonitor{}
+	state := ui.ApplicationState{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Monitor: monitor,
+		Keys:    ui.DefaultKeyMap(),
+	}
+
+	m := Metrics(state, &testhelpers.MockResource{}, 80, 10)
+	msg := m.fetch()()
+	m, cmd := m.Update(msg)
+	require.NotNil(t, cmd, "the metrics should be refreshed")
+
+	t.Run("the metrics are charted", func(t *testing.T) {
+		require.Len(t, monitor.queries, 1)
+		query := monitor.queries[0]
+		assert.Equal(t, time.Hour, query.End.Sub(query.Start))
+
+		view := m.View()
+		assert.Contains(t, view, "CPU: 0.50 cores, max 0.50 cores")
+		assert.Contains(t, view, "⠉⠉⠉")
+		assert.Contains(t, view, "No data")
+	})
+
+	t.Run("the metrics of the previous window are ignored", func(t *testing.T) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		assert.Equal(t, StatusLoading, m.status)
+		assert.Contains(t, m.View(), "last 6h")
+
+		m, cmd = m.Update(msg)
+		assert.Nil(t, cmd)
+		assert.Equal(t, StatusLoading, m.status)
+
+		_, cmd = m.Update(refreshMsg{generation: 0})
+		assert.Nil(t, cmd, "the refreshes of the previous window should stop")
+	})
+}
@@ -0,0 +1,136 @@
+package progress
+
+// A component to view the progress of the discovery and the requests that failed.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/discovery"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/mattn/go-runewidth"
+)
+
+// StatusBarHeight is the height of the status bar.
+const StatusBarHeight = 1
+
+func Progress(state ui.ApplicationState, width, height int) Model {
+	m := Model{
+		state:    state,
+		viewport: viewport.New(width, height),
+	}
+	m.Refresh()
+	return m
+}
+
+// Init initializes the progress component.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// Refresh updates the content with the latest status of the discovery.
+func (m *Model) Refresh() {
+	if m.state.Sync == nil {
+		return
+	}
+	m.viewport.SetContent(m.buildViewPortContent(m.state.Sync.Status()))
+}
+
+func (m Model) buildViewPortContent(status discovery.Status) string {
+	var b strings.Builder
+
+	for _, e := range status.Events {
+		line := strings.Join(m.eventLocation(e), " · ")
+
+		if e.Err == nil {
+			b.WriteString(fmt.Sprintf("✅ %s: %d resources", line, e.Count))
+			b.WriteRune('\n')
+			continue
+		}
+
+		b.WriteString("❌ " + line)
+		b.WriteRune('\n')
+		b.WriteString(m.state.Styles.Error.Render(runewidth.Wrap(e.Err.Error(), m.viewport.Width-4)))
+		b.WriteRune('\n')
+		if e.Hint != "" {
+			b.WriteString(m.state.Styles.Title.Render(runewidth.Wrap(e.Hint, m.viewport.Width-4)))
+			b.WriteRune('\n')
+		}
+	}
+
+	return b.String()
+}
+
+func (m Model) eventLocation(e discovery.Event) []string {
+	location := []string{e.Product}
+	if e.Locality != nil {
+		location = append(location, e.Locality.String())
+	}
+	if e.ProjectID != "" {
+		name, ok := m.state.ProjectIDsToNames[e.ProjectID]
+		if !ok {
+			name = e.ProjectID
+		}
+		location = append(location, name)
+	}
+	return location
+}
+
+func (m Model) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.viewHeader(),
+		m.state.Styles.BaseBorder.Width(m.viewport.Width).Render(m.viewport.View()),
+	)
+}
+
+func (m Model) viewHeader() string {
+	return m.state.Styles.Title.Render("Discovery")
+}
+
+func (m *Model) SetDimensions(width, height int) {
+	m.viewport.Width = width
+	m.viewport.Height = height
+	m.Refresh()
+}
+
+// StatusBar renders a single line summary of the discovery.
+func StatusBar(state ui.ApplicationState, width int) string {
+	if state.Sync == nil {
+		return ""
+	}
+
+	status := state.Sync.Status()
+
+	var text string
+	if status.Running {
+		text = fmt.Sprintf("Discovering resources: %d/%d requests", status.Done, status.Total)
+	} else {
+		text = fmt.Sprintf("Discovered %d resources", status.Count())
+	}
+
+	bar := state.Styles.Title.Render(text)
+	if failures := len(status.Failures()); failures > 0 {
+		bar += state.Styles.Error.Render(fmt.Sprintf("%d failed", failures))
+		bar += state.Styles.Title.Render(fmt.Sprintf("(press %s for details)", state.Keys.Discovery.Help().Key))
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width).Render(bar)
+}
+
+// Model is the model for the progress component.
+type Model struct {
+	// state of the application
+	state ui.ApplicationState
+	// viewport to display the events
+	viewport viewport.Model
+}
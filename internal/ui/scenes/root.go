@@ -2,6 +2,7 @@ package scenes
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"strings"
 	"time"
@@ -12,15 +13,30 @@ import (
 	"github.com/cyclimse/scwtui/internal/resource"
 	"github.com/cyclimse/scwtui/internal/ui"
 	"github.com/cyclimse/scwtui/internal/ui/actions"
+	"github.com/cyclimse/scwtui/internal/ui/browser"
+	"github.com/cyclimse/scwtui/internal/ui/bulk"
 	"github.com/cyclimse/scwtui/internal/ui/confirm"
 	"github.com/cyclimse/scwtui/internal/ui/describe"
+	"github.com/cyclimse/scwtui/internal/ui/feed"
 	"github.com/cyclimse/scwtui/internal/ui/header"
+	"github.com/cyclimse/scwtui/internal/ui/history"
 	"github.com/cyclimse/scwtui/internal/ui/journal"
+	"github.com/cyclimse/scwtui/internal/ui/metrics"
+	"github.com/cyclimse/scwtui/internal/ui/progress"
 	"github.com/cyclimse/scwtui/internal/ui/search"
 	"github.com/cyclimse/scwtui/internal/ui/table"
+	"github.com/cyclimse/scwtui/internal/ui/topology"
 )
 
-const refreshInterval = 3 * time.Second
+const (
+	// reloadInterval is the interval at which all the resources are reloaded from the store.
+	// The table is updated from the events of the index, this is only a fallback in case one was missed.
+	reloadInterval = 5 * time.Minute
+
+	// tickInterval is the interval at which the durations are updated,
+	// such as the time since the last sync and the age of the resources.
+	tickInterval = time.Second
+)
 
 func Root(state ui.ApplicationState) tea.Model {
 	m := Model{
@@ -32,39 +48,126 @@ func Root(state ui.ApplicationState) tea.Model {
 		header: header.Header(ui.TableFocused, state),
 		search: search.Search(state),
 		table:  table.Table(state),
+		feed:   feed.Feed(state),
+	}
+	if state.Index != nil {
+		m.subscription = state.Index.Subscribe()
 	}
 	m.setFocused(m.focused)
 	return &m
 }
 
-type refreshPeriodicallyMsg struct {
+type resourcesLoadedMsg struct {
 	Resources []resource.Resource
-}
 
-func refreshEvery(state ui.ApplicationState, logger *slog.Logger, d time.Duration) tea.Cmd {
-	return tea.Every(d, func(t time.Time) tea.Msg {
-		ctx, cancel := context.WithDeadline(context.Background(), t.Add(d))
-		defer cancel()
+	// generation is the load the resources belong to, only the most recent one is used.
+	generation int
+}
 
-		resources, err := state.Store.ListAllResources(ctx)
+// loadResources loads all the stored resources.
+// The table is kept up to date from the events of the index in between.
+func loadResources(state ui.ApplicationState, logger *slog.Logger, generation int) tea.Cmd {
+	return func() tea.Msg {
+		resources, err := state.Store.ListAllResources(context.Background())
 		if err != nil {
 			logger.Error("failed to list resources", slog.String("error", err.Error()))
-			return refreshPeriodicallyMsg{Resources: []resource.Resource{}}
+			return resourcesLoadedMsg{Resources: []resource.Resource{}, generation: generation}
 		}
 
-		return refreshPeriodicallyMsg{Resources: resources}
+		return resourcesLoadedMsg{Resources: resources, generation: generation}
+	}
+}
+
+type reloadPeriodicallyMsg struct{}
+
+func reloadAfter(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return reloadPeriodicallyMsg{}
 	})
 }
 
-type refreshOnceMsg struct {
-	Resources []resource.Resource
+type tickMsg struct{}
+
+func tick() tea.Cmd {
+	return tea.Tick(tickInterval, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+// applyEvents returns the resources once the events of the index are applied, in order.
+func applyEvents(resources []resource.Resource, events []resource.IndexEvent) []resource.Resource {
+	if len(events) == 0 {
+		return resources
+	}
+
+	type key struct {
+		ID   string
+		Type resource.Type
+	}
+	keyOf := func(r resource.Resource) key {
+		metadata := r.Metadata()
+		return key{ID: metadata.ID, Type: metadata.Type}
+	}
+
+	latest := make(map[key]resource.IndexEvent, len(events))
+	for _, e := range events {
+		latest[keyOf(e.Resource)] = e
+	}
+
+	applied := make([]resource.Resource, 0, len(resources)+len(latest))
+	for _, r := range resources {
+		k := keyOf(r)
+		e, ok := latest[k]
+		if !ok {
+			applied = append(applied, r)
+			continue
+		}
+		delete(latest, k)
+		if e.Kind != resource.IndexEventDelete {
+			applied = append(applied, e.Resource)
+		}
+	}
+	// the remaining events are about resources that were not loaded.
+	for _, e := range events {
+		k := keyOf(e.Resource)
+		last, ok := latest[k]
+		if !ok {
+			continue
+		}
+		delete(latest, k)
+		if last.Kind != resource.IndexEventDelete {
+			applied = append(applied, last.Resource)
+		}
+	}
+	return applied
+}
+
+type indexEventsMsg struct {
+	Events []resource.IndexEvent
+}
+
+// waitForIndexEvents waits for the next changes of the index.
+func waitForIndexEvents(subscription *resource.Subscription) tea.Cmd {
+	if subscription == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		events, err := subscription.Next(context.Background())
+		if err != nil {
+			return nil
+		}
+		return indexEventsMsg{Events: events}
+	}
 }
 
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		tea.EnterAltScreen,
-		// on startup, refresh the resources quicker than the default interval.
-		refreshEvery(m.state, m.logger, 1*time.Second),
+		// the first load starts the periodic reloads.
+		reloadAfter(0),
+		tick(),
+		waitForIndexEvents(m.subscription),
 		m.header.Init(),
 		m.search.Init(),
 		m.table.Init(),
@@ -78,7 +181,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		return m.updateWindowsResize(msg), nil
-	case refreshPeriodicallyMsg:
+	case reloadPeriodicallyMsg:
+		return m, tea.Batch(m.reload(), reloadAfter(reloadInterval))
+	case resourcesLoadedMsg:
+		// a more recent load is on its way.
+		if msg.generation != m.loadGeneration {
+			return m, nil
+		}
+
+		// the events received while loading may be more recent than the stored resources.
+		resources := applyEvents(msg.Resources, m.eventsWhileLoading)
+		m.eventsWhileLoading = nil
+		m.loading = false
+
+		m.feed.Observe(resources)
 		if m.search.Dirty() {
 			filterIDs, err := m.state.Search.Search(context.Background(), m.search.Value())
 			if err != nil {
@@ -86,17 +202,58 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			m.table.UpdateResources(ui.ApplyIDsFilter(msg.Resources, filterIDs))
+			m.table.UpdateResources(ui.ApplyIDsFilter(resources, filterIDs))
 		} else {
-			m.table.UpdateResources(msg.Resources)
+			m.table.UpdateResources(resources)
+		}
+		if m.focused == ui.DiscoveryFocused {
+			m.discovery.Refresh()
 		}
-		return m, refreshEvery(m.state, m.logger, refreshInterval)
-	case refreshOnceMsg:
-		m.table.UpdateResources(msg.Resources)
 		return m, nil
+	case indexEventsMsg:
+		m.feed.Apply(msg.Events)
+		if m.loading {
+			m.eventsWhileLoading = append(m.eventsWhileLoading, msg.Events...)
+		}
+
+		var filterIDs resource.SetOfIDs
+		if m.search.Dirty() {
+			var err error
+			filterIDs, err = m.state.Search.Search(context.Background(), m.search.Value())
+			if err != nil {
+				m.logger.Error("failed to search resources", slog.String("error", err.Error()))
+				return m, waitForIndexEvents(m.subscription)
+			}
+		}
+
+		m.table.ApplyEvents(msg.Events, filterIDs)
+		if m.focused == ui.DiscoveryFocused {
+			m.discovery.Refresh()
+		}
+		return m, waitForIndexEvents(m.subscription)
+	case tickMsg:
+		// the header and the status bar are rendered again after each message,
+		// the age of the resources is only shown in minutes.
+		m.ticks++
+		if m.ticks%int(time.Minute/tickInterval) == 0 {
+			m.table.RefreshTimes()
+		}
+		return m, tick()
 	case search.ResultsMsg:
 		m.table.UpdateResources(ui.ApplyIDsFilter(m.table.Resources(), msg.IDs))
 		return m, nil
+	case actions.SharedActionMsg:
+		m.bulk = bulk.Action(m.state, m.table.Marked(), msg.Name, m.table.Width(), m.table.Height())
+		cmd = m.setFocused(ui.BulkFocused)
+		return m, cmd
+	case bulk.ProgressMsg:
+		// the operation keeps running in the background if the user goes back to the table.
+		m.bulk, cmd = m.bulk.Update(msg)
+		if m.bulk.Finished() {
+			// keep the resources that failed selected, so that the operation can be retried.
+			m.table.SetMarked(m.bulk.Failed())
+		}
+		return m, cmd
 	case ui.Focused:
 		// this is used to set the focus asynchroniously
 		// e.g. after a resource is deleted, we want to let the user see the
@@ -104,8 +261,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd = m.setFocused(msg)
 		return m, cmd
 	case tea.KeyMsg:
+		// the inputs and the expanded logs of the focused component handle esc themselves.
+		if m.focused == ui.JournalFocused && (m.journal.Editing() || m.journal.Expanded()) {
+			return m.updateFocusedOnKeyMsg(msg)
+		}
+		if m.focused == ui.BrowserFocused && m.browser.Editing() {
+			return m.updateFocusedOnKeyMsg(msg)
+		}
+
 		switch {
 		case key.Matches(msg, m.state.Keys.Quit):
+			// esc clears the selection first.
+			if m.focused == ui.TableFocused && msg.Type == tea.KeyEsc && len(m.table.Marked()) > 0 {
+				m.table.ClearMarks()
+				return m, nil
+			}
 			if m.focused != ui.TableFocused {
 				cmd = m.setFocused(ui.TableFocused)
 				return m, cmd
@@ -135,20 +305,62 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmd = m.setFocused(ui.DescribeFocused)
 			return m, cmd
 		case key.Matches(msg, m.state.Keys.Logs):
-			canViewLogs := m.table.SelectedResource().CockpitMetadata().CanViewLogs
-			if canViewLogs {
+			if m.openJournal() {
 				cmd = m.setFocused(ui.JournalFocused)
 				return m, cmd
 			}
+		case key.Matches(msg, m.state.Keys.Metrics):
+			if selected := m.table.SelectedResource(); selected != nil && selected.CockpitMetadata().CanViewMetrics {
+				cmd = m.setFocused(ui.MetricsFocused)
+				return m, cmd
+			}
 		case key.Matches(msg, m.state.Keys.Delete):
+			if marked := m.table.Marked(); len(marked) > 0 {
+				m.bulk = bulk.Delete(m.state, marked, m.table.Width(), m.table.Height())
+				cmd = m.setFocused(ui.BulkFocused)
+				return m, cmd
+			}
+			// a project can only be deleted with all of its resources.
+			if selected := m.table.SelectedResource(); selected != nil && selected.Metadata().Type == resource.TypeProject {
+				m.bulk = bulk.DeleteProject(m.state, selected, m.table.Width(), m.table.Height())
+				cmd = m.setFocused(ui.BulkFocused)
+				return m, cmd
+			}
 			cmd = m.setFocused(ui.ConfirmFocused)
 			return m, cmd
 		case key.Matches(msg, m.state.Keys.Actions):
+			if marked := m.table.Marked(); len(marked) > 0 {
+				if len(actions.SharedActions(marked)) > 0 {
+					cmd = m.setFocused(ui.ActionsFocused)
+				}
+				return m, cmd
+			}
 			_, ok := m.table.SelectedResource().(resource.Actionable)
 			if ok {
 				cmd = m.setFocused(ui.ActionsFocused)
 				return m, cmd
 			}
+		case key.Matches(msg, m.state.Keys.Discovery):
+			cmd = m.setFocused(ui.DiscoveryFocused)
+			return m, cmd
+		case key.Matches(msg, m.state.Keys.History):
+			if m.table.SelectedResource() != nil {
+				cmd = m.setFocused(ui.HistoryFocused)
+				return m, cmd
+			}
+		case key.Matches(msg, m.state.Keys.Browse):
+			if _, ok := m.table.SelectedResource().(browser.Bucket); ok {
+				cmd = m.setFocused(ui.BrowserFocused)
+				return m, cmd
+			}
+		case key.Matches(msg, m.state.Keys.Topology):
+			if _, ok := m.table.SelectedResource().(resource.Networked); ok {
+				cmd = m.setFocused(ui.TopologyFocused)
+				return m, cmd
+			}
+		case key.Matches(msg, m.state.Keys.Feed):
+			m.showFeed = !m.showFeed
+			return m.updateWindowsResize(m.windowSize), nil
 		}
 
 		m.setFocused(ui.TableFocused)
@@ -179,6 +391,18 @@ func (m Model) updateFocusedOnKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		m.journal, cmd = m.journal.Update(msg)
 	case ui.ActionsFocused:
 		m.actions, cmd = m.actions.Update(msg)
+	case ui.DiscoveryFocused:
+		m.discovery, cmd = m.discovery.Update(msg)
+	case ui.HistoryFocused:
+		m.history, cmd = m.history.Update(msg)
+	case ui.BulkFocused:
+		m.bulk, cmd = m.bulk.Update(msg)
+	case ui.MetricsFocused:
+		m.metrics, cmd = m.metrics.Update(msg)
+	case ui.BrowserFocused:
+		m.browser, cmd = m.browser.Update(msg)
+	case ui.TopologyFocused:
+		m.topology, cmd = m.topology.Update(msg)
 	}
 
 	return m, cmd
@@ -207,13 +431,30 @@ func (m Model) updateFocusedOnAnyMsg(msg tea.Msg) (Model, tea.Cmd) {
 	case ui.JournalFocused:
 		m.journal, cmd = m.journal.Update(msg)
 	case ui.ActionsFocused:
-		if _, ok := msg.(actions.ActionResultMsg); ok {
+		if result, ok := msg.(actions.ActionResultMsg); ok {
+			// the report or the error stays shown until the user goes back to the table.
+			if actions.HasResult(result) {
+				m.actions.SetResult(result)
+				return m, nil
+			}
 			cmd = tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
 				return ui.TableFocused
 			})
 		} else {
 			m.actions, cmd = m.actions.Update(msg)
 		}
+	case ui.DiscoveryFocused:
+		m.discovery, cmd = m.discovery.Update(msg)
+	case ui.HistoryFocused:
+		m.history, cmd = m.history.Update(msg)
+	case ui.BulkFocused:
+		m.bulk, cmd = m.bulk.Update(msg)
+	case ui.MetricsFocused:
+		m.metrics, cmd = m.metrics.Update(msg)
+	case ui.BrowserFocused:
+		m.browser, cmd = m.browser.Update(msg)
+	case ui.TopologyFocused:
+		m.topology, cmd = m.topology.Update(msg)
 	}
 
 	return m, cmd
@@ -228,6 +469,15 @@ func (m Model) View() string {
 		b.WriteString(m.search.View())
 		b.WriteString("\n")
 		b.WriteString(m.table.View())
+		b.WriteString("\n")
+		b.WriteString(progress.StatusBar(m.state, m.table.Width()))
+		if marked := len(m.table.Marked()); marked > 0 {
+			b.WriteString(m.state.Styles.Title.Render(fmt.Sprintf("%d selected", marked)))
+		}
+		if m.showFeed {
+			b.WriteString("\n")
+			b.WriteString(m.feed.View())
+		}
 	case ui.DescribeFocused:
 		b.WriteString(m.describe.View())
 	case ui.ConfirmFocused: // confirm is a modal, so we need to render it on top of the table.
@@ -238,6 +488,19 @@ func (m Model) View() string {
 	case ui.ActionsFocused: // actions is a modal, so we need to render it on top of the table.
 		b.WriteString("\n\n")
 		b.WriteString(lipgloss.PlaceHorizontal(m.table.Width(), lipgloss.Center, m.actions.View()))
+	case ui.DiscoveryFocused:
+		b.WriteString(m.discovery.View())
+	case ui.HistoryFocused:
+		b.WriteString(m.history.View())
+	case ui.BulkFocused: // bulk is a modal, so we need to render it on top of the table.
+		b.WriteString("\n\n")
+		b.WriteString(lipgloss.PlaceHorizontal(m.table.Width(), lipgloss.Center, m.bulk.View()))
+	case ui.MetricsFocused:
+		b.WriteString(m.metrics.View())
+	case ui.BrowserFocused:
+		b.WriteString(m.browser.View())
+	case ui.TopologyFocused:
+		b.WriteString(m.topology.View())
 	}
 	return b.String()
 }
@@ -251,6 +514,35 @@ const (
 	fullViewExtraPaddding = 2
 )
 
+// openJournal creates the journal of the marked resources, of the selected resource,
+// or of the resources of the selected project or namespace.
+// It returns false if there are no logs to show.
+func (m *Model) openJournal() bool {
+	w, h := m.table.Width()-fullViewExtraPaddding, m.table.Height()+fullViewExtraHeight
+
+	if marked := m.table.Marked(); len(marked) > 0 {
+		m.journal = journal.Merged(m.state, marked, w, h)
+		return true
+	}
+
+	selected := m.table.SelectedResource()
+	if selected == nil {
+		return false
+	}
+	if selected.CockpitMetadata().CanViewLogs {
+		m.journal = journal.Journal(m.state, selected, w, h)
+		return true
+	}
+
+	switch selected.Metadata().Type {
+	case resource.TypeProject, resource.TypeFunctionNamespace, resource.TypeContainerNamespace, resource.TypeJobDefinition:
+		m.journal = journal.MergedChildren(m.state, selected, w, h)
+		return true
+	default:
+		return false
+	}
+}
+
 func (m *Model) setFocused(focused ui.Focused) tea.Cmd {
 	var cmd tea.Cmd
 
@@ -259,19 +551,9 @@ func (m *Model) setFocused(focused ui.Focused) tea.Cmd {
 		m.table.Focus()
 
 		// special case: if we come back from the search, we need to update the
-		// resources so that the table is up to date.
-		// this will be handled by the refreshEvery command, but we need to
-		// do it quicker than the default interval to make the UI more responsive.
+		// resources so that the table shows all of them again.
 		if m.focused == ui.SearchFocused && !m.search.Dirty() {
-			cmd = func() tea.Msg {
-				ctx := context.Background()
-				resources, err := m.state.Store.ListAllResources(ctx)
-				if err != nil {
-					m.logger.Error("failed to list resources", slog.String("error", err.Error()))
-					return refreshOnceMsg{Resources: []resource.Resource{}}
-				}
-				return refreshOnceMsg{Resources: resources}
-			}
+			cmd = m.reload()
 		}
 	case ui.SearchFocused:
 		m.table.Blur()
@@ -285,13 +567,41 @@ func (m *Model) setFocused(focused ui.Focused) tea.Cmd {
 		m.confirm = confirm.Confirm(m.state, m.table.SelectedResource(), m.table.Width(), m.table.Height())
 		cmd = m.confirm.Init()
 	case ui.JournalFocused:
+		// the journal is created beforehand, as it depends on the marked resources.
 		m.table.Blur()
-		m.journal = journal.Journal(m.state, m.table.SelectedResource(), m.table.Width()-fullViewExtraPaddding, m.table.Height()+fullViewExtraHeight)
 		cmd = m.journal.Init()
+	case ui.MetricsFocused:
+		m.table.Blur()
+		m.metrics = metrics.Metrics(m.state, m.table.SelectedResource(), m.table.Width()-fullViewExtraPaddding, m.table.Height()+fullViewExtraHeight)
+		cmd = m.metrics.Init()
+	case ui.BrowserFocused:
+		m.table.Blur()
+		m.browser = browser.Browser(m.state, m.table.SelectedResource().(browser.Bucket), m.table.Width()-fullViewExtraPaddding, m.table.Height()+fullViewExtraHeight)
+		cmd = m.browser.Init()
+	case ui.TopologyFocused:
+		m.table.Blur()
+		m.topology = topology.Topology(m.state, m.table.SelectedResource().(resource.Networked), m.table.Width()-fullViewExtraPaddding, m.table.Height()+fullViewExtraHeight)
+		cmd = m.topology.Init()
 	case ui.ActionsFocused:
 		m.table.Blur()
-		m.actions = actions.Actions(m.state, m.table.SelectedResource().(resource.Actionable), m.table.Width(), m.table.Height())
+		if marked := m.table.Marked(); len(marked) > 0 {
+			m.actions = actions.Shared(m.state, marked, m.table.Width(), m.table.Height())
+		} else {
+			m.actions = actions.Actions(m.state, m.table.SelectedResource().(resource.Actionable), m.table.Width(), m.table.Height())
+		}
 		cmd = m.actions.Init()
+	case ui.DiscoveryFocused:
+		m.table.Blur()
+		m.discovery = progress.Progress(m.state, m.table.Width()-fullViewExtraPaddding, m.table.Height()+fullViewExtraHeight)
+		cmd = m.discovery.Init()
+	case ui.HistoryFocused:
+		m.table.Blur()
+		m.history = history.History(m.state, m.table.SelectedResource(), m.table.Width()-fullViewExtraPaddding, m.table.Height()+fullViewExtraHeight)
+		cmd = m.history.Init()
+	case ui.BulkFocused:
+		// the bulk component is created beforehand, as it depends on the operation.
+		m.table.Blur()
+		cmd = m.bulk.Init()
 	}
 
 	m.focused = focused
@@ -301,8 +611,15 @@ func (m *Model) setFocused(focused ui.Focused) tea.Cmd {
 }
 
 func (m Model) updateWindowsResize(msg tea.WindowSizeMsg) Model {
+	m.windowSize = msg
 	m.header.SetWidth(msg.Width)
-	m.table.SetDimensions(msg.Width, msg.Height)
+
+	// leave room for the status bar and the change feed below the table.
+	tableHeight := msg.Height - progress.StatusBarHeight
+	if m.showFeed {
+		tableHeight -= feed.Height
+	}
+	m.table.SetDimensions(msg.Width, tableHeight)
 
 	w := m.table.Width()
 	h := m.table.Height()
@@ -310,6 +627,13 @@ func (m Model) updateWindowsResize(msg tea.WindowSizeMsg) Model {
 	// Resize the other components to the table's dimensions.
 	m.describe.SetDimensions(w-fullViewExtraPaddding, h+fullViewExtraHeight)
 	m.journal.SetDimensions(w-fullViewExtraPaddding, h+fullViewExtraHeight)
+	m.metrics.SetDimensions(w-fullViewExtraPaddding, h+fullViewExtraHeight)
+	m.browser.SetDimensions(w-fullViewExtraPaddding, h+fullViewExtraHeight)
+	m.topology.SetDimensions(w-fullViewExtraPaddding, h+fullViewExtraHeight)
+	m.discovery.SetDimensions(w-fullViewExtraPaddding, h+fullViewExtraHeight)
+	m.history.SetDimensions(w-fullViewExtraPaddding, h+fullViewExtraHeight)
+	m.bulk.SetDimensions(w, h)
+	m.feed.SetWidth(w)
 	return m
 }
 
@@ -325,5 +649,38 @@ type Model struct {
 	table    table.Model
 	confirm  confirm.Model
 	journal  journal.Model
+	metrics  metrics.Model
+	browser  browser.Model
+	topology topology.Model
 	actions  actions.Model
+
+	discovery progress.Model
+	history   history.Model
+	bulk      bulk.Model
+
+	// feed is shown below the table when toggled.
+	feed     feed.Model
+	showFeed bool
+
+	windowSize tea.WindowSizeMsg
+
+	// subscription to the changes of the index, to update the table.
+	subscription *resource.Subscription
+
+	// loadGeneration identifies the last load of the resources, the previous ones are ignored.
+	loadGeneration int
+	loading        bool
+	// eventsWhileLoading are applied to the loaded resources, as they may be more recent.
+	eventsWhileLoading []resource.IndexEvent
+
+	// ticks is the number of ticks since the start.
+	ticks int
+}
+
+// reload loads all the resources again, the ones of the previous loads are ignored.
+func (m *Model) reload() tea.Cmd {
+	m.loadGeneration++
+	m.loading = true
+	m.eventsWhileLoading = nil
+	return loadResources(m.state, m.logger, m.loadGeneration)
 }
@@ -0,0 +1,69 @@
+package scenes
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/cyclimse/scwtui/internal/ui"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockResource(name string) *testhelpers.MockResource {
+	return &testhelpers.MockResource{
+		MetadataValue: resource.Metadata{
+			ID:       name + "-id",
+			Name:     name,
+			Type:     resource.TypeContainer,
+			Locality: resource.Region(scw.RegionFrPar),
+		},
+	}
+}
+
+func names(resources []resource.Resource) []string {
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.Metadata().Name)
+	}
+	return names
+}
+
+func TestModel_Reload(t *testing.T) {
+	state := ui.ApplicationState{
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Keys:   ui.DefaultKeyMap(),
+		Styles: ui.DefaultStyles(),
+	}
+	m := *Root(state).(*Model)
+
+	update := func(msg tea.Msg) {
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+
+	a, b, c := mockResource("a"), mockResource("b"), mockResource("c")
+
+	// a first load is superseded by a second one, for instance when leaving the search.
+	update(reloadPeriodicallyMsg{})
+	update(reloadPeriodicallyMsg{})
+	require.Equal(t, 2, m.loadGeneration)
+
+	// b is deleted and c created while loading.
+	update(indexEventsMsg{Events: []resource.IndexEvent{
+		{Kind: resource.IndexEventDelete, Resource: b},
+		{Kind: resource.IndexEventUpsert, Resource: c},
+	}})
+
+	update(resourcesLoadedMsg{Resources: []resource.Resource{a}, generation: 1})
+	assert.Equal(t, []string{"c"}, names(m.table.Resources()), "the previous loads should be ignored")
+
+	// the stored resources were listed before b was deleted.
+	update(resourcesLoadedMsg{Resources: []resource.Resource{a, b}, generation: 2})
+	assert.ElementsMatch(t, []string{"a", "c"}, names(m.table.Resources()), "the events received while loading should be applied")
+	assert.False(t, m.loading)
+}
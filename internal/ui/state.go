@@ -3,6 +3,7 @@ package ui
 import (
 	"log/slog"
 
+	"github.com/cyclimse/scwtui/internal/discovery"
 	"github.com/cyclimse/scwtui/internal/resource"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
@@ -14,7 +15,9 @@ type ApplicationState struct {
 
 	Store   resource.Storer
 	Search  resource.Searcher
+	Index   *resource.Index
 	Monitor resource.Monitorer
+	Sync    discovery.Syncer
 
 	ScwClient         *scw.Client
 	ScwProfileName    string
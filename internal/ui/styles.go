@@ -9,6 +9,18 @@ type Styles struct {
 
 	ModalWidth int
 	Modal      lipgloss.Style
+
+	// LogDebug, LogWarning and LogError color the logs by level.
+	LogDebug   lipgloss.Style
+	LogWarning lipgloss.Style
+	LogError   lipgloss.Style
+	// LogSelected highlights the selected log.
+	LogSelected lipgloss.Style
+	// LogSources color the names of the resources when their logs are merged.
+	LogSources []lipgloss.Style
+
+	// Chart is the style of the charts of the metrics.
+	Chart lipgloss.Style
 }
 
 func DefaultStyles() Styles {
@@ -28,5 +40,21 @@ func DefaultStyles() Styles {
 			Padding(0, 1),
 		ModalWidth: modalWidth,
 		Modal:      lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true).Width(modalWidth).Padding(1, 2),
+		LogDebug:   lipgloss.NewStyle().Foreground(lipgloss.Color("243")),
+		LogWarning: lipgloss.NewStyle().Foreground(lipgloss.Color("11")),
+		LogError:   lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		LogSelected: lipgloss.NewStyle().
+			Background(lipgloss.Color("237")).
+			Bold(true),
+		// the colors of the levels are left out, so that the names are not mistaken for them.
+		LogSources: []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(lipgloss.Color("14")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("10")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("208")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("141")),
+		},
+		Chart: lipgloss.NewStyle().Foreground(lipgloss.Color("12")),
 	}
 }
@@ -45,41 +45,32 @@ var (
 )
 
 func NewBuilder(styles table.Styles) *Build {
-	b := &Build{
+	return &Build{
 		styles:       styles,
 		timeagoCache: make(map[time.Time]string),
 		mutex:        &sync.Mutex{},
 	}
+}
 
-	timer := time.NewTicker(time.Minute)
-
-	go func() {
-		// purge the cache every minute to allow timeago to update.
-		// eg. "a few seconds ago" -> "a minute ago"
-		for range timer.C {
-			b.mutex.Lock()
-			b.timeagoCache = make(map[time.Time]string)
-			b.mutex.Unlock()
-		}
-	}()
+// PurgeTimes clears the cache of the formatted times, to allow timeago to update.
+// eg. "a few seconds ago" -> "a minute ago"
+func (b *Build) PurgeTimes() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 
-	return b
+	b.timeagoCache = make(map[time.Time]string)
 }
 
 type BuildParams struct {
 	Width             int
 	AltView           bool
+	Sorting           Sorting
 	Resources         []resource.Resource
 	ProjectIDsToNames map[string]string
 }
 
 func (b *Build) Build(params BuildParams, opts ...table.Option) table.Model {
-	var rows []table.Row
-	if !params.AltView {
-		rows = b.buildRows(params)
-	} else {
-		rows = b.buildRowsAlt(params)
-	}
+	rows := b.buildRows(params)
 	cols := b.buildCols(params)
 
 	return table.New(append(
@@ -90,48 +81,48 @@ func (b *Build) Build(params BuildParams, opts ...table.Option) table.Model {
 }
 
 func (b *Build) buildRows(params BuildParams) []table.Row {
-	resources := params.Resources
-	rows := make([]table.Row, 0, len(resources))
-
-	for _, r := range resources {
-		metadata := r.Metadata()
-
-		rows = append(rows, table.Row{
-			lipgloss.PlaceHorizontal(6, lipgloss.Center, string(metadata.Status.Emoji(metadata.Type))),
-			metadata.Name,
-			metadata.Type.String(),
-			params.ProjectIDsToNames[metadata.ProjectID],
-			b.formattedTimeAgo(metadata.CreatedAt),
-			metadata.Locality.String(),
-		})
+	rows := make([]table.Row, 0, len(params.Resources))
+	for _, r := range params.Resources {
+		rows = append(rows, b.Row(params, r))
 	}
-
 	return rows
 }
 
-func (b *Build) buildRowsAlt(params BuildParams) []table.Row {
-	resources := params.Resources
-	rows := make([]table.Row, 0, len(resources))
+// Row builds the row of a single resource.
+// The resources of the params are ignored.
+func (b *Build) Row(params BuildParams, r resource.Resource) table.Row {
+	if params.AltView {
+		return b.rowAlt(params, r)
+	}
+
+	metadata := r.Metadata()
 
-	for _, r := range resources {
-		metadata := r.Metadata()
+	return table.Row{
+		lipgloss.PlaceHorizontal(6, lipgloss.Center, string(metadata.Status.Emoji(metadata.Type))),
+		metadata.Name,
+		metadata.Type.String(),
+		params.ProjectIDsToNames[metadata.ProjectID],
+		b.formattedTimeAgo(metadata.CreatedAt),
+		metadata.Locality.String(),
+	}
+}
 
-		createdAt := ""
-		if metadata.CreatedAt != nil {
-			createdAt = metadata.CreatedAt.Format(time.RFC3339)
-		}
+func (b *Build) rowAlt(_ BuildParams, r resource.Resource) table.Row {
+	metadata := r.Metadata()
 
-		rows = append(rows, table.Row{
-			lipgloss.PlaceHorizontal(6, lipgloss.Center, string(metadata.Status.Emoji(metadata.Type))),
-			metadata.ID,
-			metadata.Type.String(),
-			metadata.ProjectID,
-			createdAt,
-			metadata.Locality.String(),
-		})
+	createdAt := ""
+	if metadata.CreatedAt != nil {
+		createdAt = metadata.CreatedAt.Format(time.RFC3339)
 	}
 
-	return rows
+	return table.Row{
+		lipgloss.PlaceHorizontal(6, lipgloss.Center, string(metadata.Status.Emoji(metadata.Type))),
+		metadata.ID,
+		metadata.Type.String(),
+		metadata.ProjectID,
+		createdAt,
+		metadata.Locality.String(),
+	}
 }
 
 // reduce the magic numbers.
@@ -162,7 +153,7 @@ func (b *Build) buildCols(params BuildParams) []table.Column {
 	}
 
 	cols := make([]table.Column, 0, len(titles))
-	for _, title := range titles {
+	for i, title := range titles {
 		var width int
 		if w, ok := columnsWithFixedWidth[title]; ok {
 			width = w
@@ -173,6 +164,7 @@ func (b *Build) buildCols(params BuildParams) []table.Column {
 		cols = append(cols, table.Column{
 			Title: title,
 			Width: width,
+			Sort:  params.Sorting.order(i),
 		})
 	}
 
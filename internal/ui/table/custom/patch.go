@@ -1,5 +1,7 @@
 package custom
 
+import "github.com/mattn/go-runewidth"
+
 func (m *Model) SetYOffset(n int) {
 	m.viewport.YOffset = n
 	m.UpdateViewport()
@@ -8,3 +10,34 @@ func (m *Model) SetYOffset(n int) {
 func (m *Model) YOffset() int {
 	return m.viewport.YOffset
 }
+
+// SortOrder is the order in which a column is sorted.
+type SortOrder int
+
+const (
+	NotSorted SortOrder = iota
+	SortAscending
+	SortDescending
+)
+
+// sortedTitle returns the title of the column with an arrow if the column is sorted.
+func sortedTitle(col Column) string {
+	var arrow string
+	switch col.Sort {
+	case SortAscending:
+		arrow = "▲"
+	case SortDescending:
+		arrow = "▼"
+	default:
+		return runewidth.Truncate(col.Title, col.Width, "…")
+	}
+
+	// keep room for the arrow.
+	return runewidth.Truncate(col.Title, col.Width-runewidth.StringWidth(arrow)-1, "…") + " " + arrow
+}
+
+// SetMarkedRows sets the rows that are rendered with the marked style.
+func (m *Model) SetMarkedRows(rows map[int]struct{}) {
+	m.marked = rows
+	m.UpdateViewport()
+}
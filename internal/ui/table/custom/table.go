@@ -24,6 +24,8 @@ type Model struct {
 	viewport viewport.Model
 	start    int
 	end      int
+
+	marked map[int]struct{}
 }
 
 // Row represents one line in the table.
@@ -33,6 +35,7 @@ type Row []string
 type Column struct {
 	Title string
 	Width int
+	Sort  SortOrder
 }
 
 // KeyMap defines keybindings. It satisfies to the help.KeyMap interface, which
@@ -50,7 +53,6 @@ type KeyMap struct {
 
 // DefaultKeyMap returns a default set of keybindings.
 func DefaultKeyMap() KeyMap {
-	const spacebar = " "
 	return KeyMap{
 		LineUp: key.NewBinding(
 			key.WithKeys("up", "k"),
@@ -65,7 +67,7 @@ func DefaultKeyMap() KeyMap {
 			key.WithHelp("b/pgup", "page up"),
 		),
 		PageDown: key.NewBinding(
-			key.WithKeys("f", "pgdown", spacebar),
+			key.WithKeys("f", "pgdown"),
 			key.WithHelp("f/pgdn", "page down"),
 		),
 		HalfPageUp: key.NewBinding(
@@ -93,6 +95,7 @@ type Styles struct {
 	Header   lipgloss.Style
 	Cell     lipgloss.Style
 	Selected lipgloss.Style
+	Marked   lipgloss.Style
 }
 
 // DefaultStyles returns a set of default style definitions for this table.
@@ -101,6 +104,7 @@ func DefaultStyles() Styles {
 		Selected: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")),
 		Header:   lipgloss.NewStyle().Bold(true).Padding(0, 1),
 		Cell:     lipgloss.NewStyle().Padding(0, 1),
+		Marked:   lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
 	}
 }
 
@@ -384,7 +388,7 @@ func (m Model) headersView() string {
 	s := make([]string, 0, len(m.cols))
 	for _, col := range m.cols {
 		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
-		renderedCell := style.Render(runewidth.Truncate(col.Title, col.Width, "…"))
+		renderedCell := style.Render(sortedTitle(col))
 		s = append(s, m.styles.Header.Render(renderedCell))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Left, s...)
@@ -404,6 +408,10 @@ func (m *Model) renderRow(rowID int) string {
 		return m.styles.Selected.Render(row)
 	}
 
+	if _, ok := m.marked[rowID]; ok {
+		return m.styles.Marked.Render(row)
+	}
+
 	return row
 }
 
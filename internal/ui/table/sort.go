@@ -0,0 +1,159 @@
+package table
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	table "github.com/cyclimse/scwtui/internal/ui/table/custom"
+)
+
+// Sorting is the column the resources are sorted by.
+// The columns are the same in the default and the alternative view,
+// for instance the "Name" column becomes the "ID" column.
+type Sorting struct {
+	// Column is the index of the column in the titles.
+	Column int
+	// Descending is true if the resources are sorted in descending order.
+	Descending bool
+}
+
+const (
+	columnStatus = iota
+	columnName
+	columnType
+	columnProject
+	columnCreated
+	columnLocality
+)
+
+// defaultSorting follows the order of the store.
+//
+//nolint:gochecknoglobals
+var defaultSorting = Sorting{Column: columnName}
+
+// Next sorts by the next column, in ascending order.
+func (s Sorting) Next() Sorting {
+	return Sorting{Column: (s.Column + 1) % len(titles)}
+}
+
+// Reversed sorts by the same column, in the other order.
+func (s Sorting) Reversed() Sorting {
+	return Sorting{Column: s.Column, Descending: !s.Descending}
+}
+
+// order returns the order of the column at the given index.
+func (s Sorting) order(column int) table.SortOrder {
+	switch {
+	case column != s.Column:
+		return table.NotSorted
+	case s.Descending:
+		return table.SortDescending
+	default:
+		return table.SortAscending
+	}
+}
+
+// compare compares two resources on the sorted column.
+// The name and the id are used to break ties, so that the order is stable between refreshes.
+func (s Sorting) compare(a, b resource.Metadata, altView bool, projectIDsToNames map[string]string) int {
+	var c int
+
+	switch s.Column {
+	case columnStatus:
+		c = strings.Compare(statusOf(a), statusOf(b))
+	case columnName:
+		if altView {
+			c = strings.Compare(a.ID, b.ID)
+		} else {
+			c = strings.Compare(a.Name, b.Name)
+		}
+	case columnType:
+		c = strings.Compare(a.Type.String(), b.Type.String())
+	case columnProject:
+		if altView {
+			c = strings.Compare(a.ProjectID, b.ProjectID)
+		} else {
+			c = strings.Compare(projectIDsToNames[a.ProjectID], projectIDsToNames[b.ProjectID])
+		}
+	case columnCreated:
+		c = createdAtOf(a).Compare(createdAtOf(b))
+	case columnLocality:
+		c = strings.Compare(localityOf(a), localityOf(b))
+	}
+
+	if s.Descending {
+		c = -c
+	}
+
+	if c == 0 {
+		c = strings.Compare(a.Name, b.Name)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+
+	return c
+}
+
+func statusOf(m resource.Metadata) string {
+	if m.Status == nil {
+		return ""
+	}
+	return string(*m.Status)
+}
+
+func createdAtOf(m resource.Metadata) time.Time {
+	if m.CreatedAt == nil {
+		return time.Time{}
+	}
+	return *m.CreatedAt
+}
+
+func localityOf(m resource.Metadata) string {
+	if m.Locality == nil {
+		return ""
+	}
+	return m.Locality.String()
+}
+
+// sortedResources sorts the resources and their rows together.
+type sortedResources struct {
+	resources []resource.Resource
+	metadata  []resource.Metadata
+	rows      []table.Row
+	less      func(a, b resource.Metadata) bool
+}
+
+func (s sortedResources) Len() int { return len(s.resources) }
+
+func (s sortedResources) Less(i, j int) bool {
+	return s.less(s.metadata[i], s.metadata[j])
+}
+
+func (s sortedResources) Swap(i, j int) {
+	s.resources[i], s.resources[j] = s.resources[j], s.resources[i]
+	s.metadata[i], s.metadata[j] = s.metadata[j], s.metadata[i]
+	if s.rows != nil {
+		s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+	}
+}
+
+// sortResources sorts the resources in place. If rows is not nil, the rows are sorted along.
+func (m Model) sortResources(resources []resource.Resource, rows []table.Row) {
+	// the metadata is computed once, as it can be expensive to build.
+	metadata := make([]resource.Metadata, 0, len(resources))
+	for _, r := range resources {
+		metadata = append(metadata, r.Metadata())
+	}
+
+	sort.Sort(sortedResources{
+		resources: resources,
+		metadata:  metadata,
+		rows:      rows,
+		less: func(a, b resource.Metadata) bool {
+			return m.sorting.compare(a, b, m.showingAltView, m.state.ProjectIDsToNames) < 0
+		},
+	})
+}
@@ -30,6 +30,9 @@ func Table(state ui.ApplicationState) Model {
 		Foreground(lipgloss.Color("229")).
 		Background(lipgloss.Color("57")).
 		Bold(false)
+	s.Marked = s.Marked.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("238"))
 
 	b := NewBuilder(s)
 
@@ -37,6 +40,9 @@ func Table(state ui.ApplicationState) Model {
 		state:          state,
 		builder:        b,
 		lastWidthBuilt: defaultWidth,
+		sorting:        defaultSorting,
+		expanded:       make(map[resourceKey]bool),
+		marked:         make(map[resourceKey]struct{}),
 	}
 }
 
@@ -51,9 +57,28 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
-		if key.Matches(msg, m.state.Keys.ToggleAltView) {
+		switch {
+		case key.Matches(msg, m.state.Keys.ToggleAltView):
 			m.toggleAltView()
 			return m, cmd
+		case key.Matches(msg, m.state.Keys.SortColumn):
+			m.SetSorting(m.sorting.Next())
+			return m, cmd
+		case key.Matches(msg, m.state.Keys.SortOrder):
+			m.SetSorting(m.sorting.Reversed())
+			return m, cmd
+		case key.Matches(msg, m.state.Keys.ToggleTreeView):
+			m.toggleTreeView()
+			return m, cmd
+		case key.Matches(msg, m.state.Keys.ToggleNode):
+			m.toggleNode()
+			return m, cmd
+		case key.Matches(msg, m.state.Keys.Mark):
+			m.toggleMark()
+			return m, cmd
+		case key.Matches(msg, m.state.Keys.MarkAll):
+			m.toggleMarkAll()
+			return m, cmd
 		}
 	}
 
@@ -63,7 +88,132 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 func (m *Model) toggleAltView() {
 	m.showingAltView = !m.showingAltView
+	// the ids and names are not in the same order.
+	m.sortResources(m.resources, nil)
+	m.rebuildTable()
+}
+
+func (m *Model) toggleTreeView() {
+	selected := m.selectedKey()
+	m.showingTree = !m.showingTree
 	m.rebuildTable()
+	m.selectResource(selected)
+}
+
+// toggleNode expands or collapses the selected node of the tree.
+func (m *Model) toggleNode() {
+	if !m.showingTree {
+		return
+	}
+
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.treeRows) {
+		return
+	}
+
+	row := m.treeRows[cursor]
+	if len(row.node.children) == 0 {
+		return
+	}
+
+	m.expanded[row.node.key] = !m.isExpanded(row.node, row.depth)
+	m.rebuildTable()
+}
+
+// isExpanded returns true if the children of the node are visible.
+// By default, only the projects are expanded.
+func (m Model) isExpanded(node *treeNode, depth int) bool {
+	if expanded, ok := m.expanded[node.key]; ok {
+		return expanded
+	}
+	return depth == 0
+}
+
+// toggleMark adds or removes the selected resource from the marked resources.
+func (m *Model) toggleMark() {
+	r := m.SelectedResource()
+	if r == nil {
+		return
+	}
+
+	k := keyOf(r)
+	if _, ok := m.marked[k]; ok {
+		delete(m.marked, k)
+	} else {
+		m.marked[k] = struct{}{}
+	}
+	m.refreshMarkedRows()
+}
+
+// toggleMarkAll marks all the resources of the table, e.g. the results of the search.
+// If they are all marked already, the marks are cleared instead.
+func (m *Model) toggleMarkAll() {
+	if len(m.Marked()) == len(m.resources) {
+		m.ClearMarks()
+		return
+	}
+
+	for _, r := range m.resources {
+		m.marked[keyOf(r)] = struct{}{}
+	}
+	m.refreshMarkedRows()
+}
+
+// Marked returns the marked resources that are still in the table.
+func (m Model) Marked() []resource.Resource {
+	marked := make([]resource.Resource, 0, len(m.marked))
+	for _, r := range m.resources {
+		if _, ok := m.marked[keyOf(r)]; ok {
+			marked = append(marked, r)
+		}
+	}
+	return marked
+}
+
+// ClearMarks removes all the marks.
+func (m *Model) ClearMarks() {
+	m.SetMarked(nil)
+}
+
+// SetMarked replaces the marked resources.
+func (m *Model) SetMarked(resources []resource.Resource) {
+	m.marked = make(map[resourceKey]struct{}, len(resources))
+	for _, r := range resources {
+		m.marked[keyOf(r)] = struct{}{}
+	}
+	m.refreshMarkedRows()
+}
+
+// refreshMarkedRows must be called when the marks or the order of the rows change.
+func (m *Model) refreshMarkedRows() {
+	rows := make(map[int]struct{}, len(m.marked))
+	for i, r := range m.visibleResources() {
+		if _, ok := m.marked[keyOf(r)]; ok {
+			rows[i] = struct{}{}
+		}
+	}
+	m.table.SetMarkedRows(rows)
+}
+
+func (m Model) selectedKey() *resourceKey {
+	r := m.SelectedResource()
+	if r == nil {
+		return nil
+	}
+	k := keyOf(r)
+	return &k
+}
+
+// SetSorting sorts the resources by another column.
+func (m *Model) SetSorting(sorting Sorting) {
+	m.sorting = sorting
+	m.sortResources(m.resources, nil)
+	m.rebuildTable()
+}
+
+// Sorting returns the column the resources are sorted by.
+func (m Model) Sorting() Sorting {
+	return m.sorting
 }
 
 func (m *Model) rebuildTable() {
@@ -79,37 +229,199 @@ func (m *Model) rebuildTable() {
 		yoffset: m.table.YOffset(),
 	}
 
-	m.table = m.builder.Build(BuildParams{
-		Width:             m.lastWidthBuilt,
-		AltView:           m.showingAltView,
-		Resources:         m.resources,
-		ProjectIDsToNames: m.state.ProjectIDsToNames,
-	})
+	params := m.buildParams()
+	params.Resources = m.resources
+	if m.showingTree {
+		m.treeRows = flattenTree(buildTree(m.resources), m.isExpanded)
+		params.Resources = m.visibleResources()
+	}
+	m.table = m.builder.Build(params)
+
+	if m.showingTree {
+		rows := m.table.Rows()
+		for i, row := range m.treeRows {
+			rows[i][columnName] = row.decorate(rows[i][columnName], m.isExpanded(row.node, row.depth))
+		}
+		m.table.SetRows(rows)
+	}
 
 	m.table.SetWidth(previous.width)
 	m.table.SetHeight(previous.height)
 	m.table.SetCursor(previous.cursor)
+	m.refreshMarkedRows()
 
 	if previous.yoffset > 0 {
 		m.table.SetYOffset(previous.yoffset)
 	}
 }
 
+func (m Model) buildParams() BuildParams {
+	return BuildParams{
+		Width:             m.lastWidthBuilt,
+		AltView:           m.showingAltView,
+		Sorting:           m.sorting,
+		ProjectIDsToNames: m.state.ProjectIDsToNames,
+	}
+}
+
+// RefreshTimes builds the rows again, so that the age of the resources is up to date.
+func (m *Model) RefreshTimes() {
+	m.builder.PurgeTimes()
+	m.rebuildTable()
+}
+
 func (m Model) Resources() []resource.Resource {
 	return m.resources
 }
 
 func (m *Model) UpdateResources(resources []resource.Resource) {
 	m.resources = resources
+	m.sortResources(m.resources, nil)
 	m.rebuildTable()
 }
 
+type resourceKey struct {
+	ID   string
+	Type resource.Type
+}
+
+func keyOf(r resource.Resource) resourceKey {
+	metadata := r.Metadata()
+	return resourceKey{ID: metadata.ID, Type: metadata.Type}
+}
+
+// ApplyEvents updates the resources from the events of the index.
+// Only the rows of the resources that changed are rebuilt, and the selected resource stays selected.
+// If filter is not nil, the resources that are not part of it are removed.
+func (m *Model) ApplyEvents(events []resource.IndexEvent, filter resource.SetOfIDs) {
+	// only the last event of a resource matters.
+	latest := make(map[resourceKey]resource.IndexEvent, len(events))
+	for _, e := range events {
+		latest[keyOf(e.Resource)] = e
+	}
+
+	keep := func(e resource.IndexEvent) bool {
+		if e.Kind == resource.IndexEventDelete {
+			return false
+		}
+		if filter == nil {
+			return true
+		}
+		_, ok := filter[e.Resource.Metadata().ID]
+		return ok
+	}
+
+	selected := m.selectedKey()
+
+	// in the tree view, the rows do not follow the resources,
+	// so the whole tree is rebuilt instead.
+	incremental := !m.showingTree
+
+	params := m.buildParams()
+	previousRows := m.table.Rows()
+	row := func(r resource.Resource) table.Row {
+		if !incremental {
+			return nil
+		}
+		return m.builder.Row(params, r)
+	}
+
+	resources := make([]resource.Resource, 0, len(m.resources))
+	rows := make([]table.Row, 0, len(m.resources))
+
+	for i, r := range m.resources {
+		k := keyOf(r)
+		e, ok := latest[k]
+		if !ok && (!incremental || i < len(previousRows)) {
+			resources = append(resources, r)
+			if incremental {
+				rows = append(rows, previousRows[i])
+			}
+			continue
+		}
+		if !ok {
+			e = resource.IndexEvent{Kind: resource.IndexEventUpsert, Resource: r}
+		}
+		delete(latest, k)
+
+		if !keep(e) {
+			continue
+		}
+
+		resources = append(resources, e.Resource)
+		rows = append(rows, row(e.Resource))
+	}
+
+	// the remaining events are about resources that were not in the table.
+	for _, e := range latest {
+		if !keep(e) {
+			continue
+		}
+
+		resources = append(resources, e.Resource)
+		rows = append(rows, row(e.Resource))
+	}
+
+	yoffset := m.table.YOffset()
+	m.resources = resources
+
+	// any of the sorted columns may have changed.
+	if incremental {
+		m.sortResources(resources, rows)
+		m.table.SetRows(rows)
+	} else {
+		m.sortResources(resources, nil)
+		m.rebuildTable()
+	}
+
+	m.selectResource(selected)
+	m.refreshMarkedRows()
+
+	if yoffset > 0 {
+		m.table.SetYOffset(yoffset)
+	}
+}
+
+// selectResource moves the cursor to the given resource.
+// If the resource is not in the table, the cursor stays at the same position.
+func (m *Model) selectResource(selected *resourceKey) {
+	cursor := m.table.Cursor()
+	if selected != nil {
+		for i, r := range m.visibleResources() {
+			if keyOf(r) == *selected {
+				cursor = i
+				break
+			}
+		}
+	}
+	m.table.SetCursor(cursor)
+}
+
+// visibleResources returns the resources in the order of the rows.
+func (m Model) visibleResources() []resource.Resource {
+	if !m.showingTree {
+		return m.resources
+	}
+
+	resources := make([]resource.Resource, 0, len(m.treeRows))
+	for _, row := range m.treeRows {
+		resources = append(resources, row.node.resource)
+	}
+	return resources
+}
+
 func (m *Model) SelectedResource() resource.Resource {
-	if m.table.Cursor() < 0 || m.table.Cursor() >= len(m.resources) {
-		return nil
+	if !m.showingTree {
+		if m.table.Cursor() < 0 || m.table.Cursor() >= len(m.resources) {
+			return nil
+		}
+		return m.resources[m.table.Cursor()]
 	}
 
-	return m.resources[m.table.Cursor()]
+	if m.table.Cursor() < 0 || m.table.Cursor() >= len(m.treeRows) {
+		return nil
+	}
+	return m.treeRows[m.table.Cursor()].node.resource
 }
 
 func (m Model) View() string {
@@ -153,4 +465,15 @@ type Model struct {
 	lastHeight     int
 
 	showingAltView bool
+	sorting        Sorting
+
+	// showingTree is true when the resources are nested under their project and parent.
+	showingTree bool
+	// treeRows are the visible nodes of the tree, in the order of the rows.
+	treeRows []treeRow
+	// expanded holds the nodes that were expanded or collapsed by the user.
+	expanded map[resourceKey]bool
+
+	// marked holds the resources selected for a bulk operation.
+	marked map[resourceKey]struct{}
 }
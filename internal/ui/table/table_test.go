@@ -0,0 +1,190 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	"github.com/cyclimse/scwtui/internal/testhelpers"
+	"github.com/cyclimse/scwtui/internal/ui"
+	fnc_sdk "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockResource(name string) *testhelpers.MockResource {
+	return &testhelpers.MockResource{
+		MetadataValue: resource.Metadata{
+			ID:       name + "-id",
+			Name:     name,
+			Type:     resource.TypeContainer,
+			Locality: resource.Region(scw.RegionFrPar),
+		},
+	}
+}
+
+func names(resources []resource.Resource) []string {
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.Metadata().Name)
+	}
+	return names
+}
+
+func TestModel_ApplyEvents(t *testing.T) {
+	a, c, e := mockResource("a"), mockResource("c"), mockResource("e")
+
+	m := Table(ui.ApplicationState{Keys: ui.DefaultKeyMap()})
+	m.SetDimensions(120, 40)
+	m.UpdateResources([]resource.Resource{a, c, e})
+	m.table.SetCursor(1)
+	require.Equal(t, c, m.SelectedResource())
+
+	t.Run("selected resource stays selected", func(t *testing.T) {
+		renamed := mockResource("d")
+		renamed.MetadataValue.ID = a.MetadataValue.ID
+
+		m.ApplyEvents([]resource.IndexEvent{
+			{Kind: resource.IndexEventUpsert, Resource: mockResource("b")},
+			{Kind: resource.IndexEventUpsert, Resource: renamed},
+			{Kind: resource.IndexEventUpsert, Resource: mockResource("0")},
+		}, nil)
+
+		assert.Equal(t, []string{"0", "b", "c", "d", "e"}, names(m.Resources()))
+		assert.Len(t, m.table.Rows(), 5)
+		assert.Equal(t, "b", m.table.Rows()[1][1])
+		assert.Equal(t, c, m.SelectedResource())
+	})
+
+	t.Run("deleted resources are removed", func(t *testing.T) {
+		m.ApplyEvents([]resource.IndexEvent{
+			{Kind: resource.IndexEventDelete, Resource: c},
+		}, nil)
+
+		assert.Equal(t, []string{"0", "b", "d", "e"}, names(m.Resources()))
+		assert.Equal(t, "d", m.SelectedResource().Metadata().Name)
+	})
+
+	t.Run("resources outside of the filter are removed", func(t *testing.T) {
+		m.ApplyEvents([]resource.IndexEvent{
+			{Kind: resource.IndexEventUpsert, Resource: e},
+			{Kind: resource.IndexEventUpsert, Resource: mockResource("f")},
+		}, resource.SetOfIDs{"f-id": {}})
+
+		assert.Equal(t, []string{"0", "b", "d", "f"}, names(m.Resources()))
+	})
+}
+
+func TestModel_SetSorting(t *testing.T) {
+	older, newer, unknown := mockResource("a"), mockResource("b"), mockResource("c")
+
+	now := time.Now()
+	older.MetadataValue.CreatedAt = &now
+	later := now.Add(time.Hour)
+	newer.MetadataValue.CreatedAt = &later
+
+	m := Table(ui.ApplicationState{Keys: ui.DefaultKeyMap()})
+	m.SetDimensions(120, 40)
+	m.UpdateResources([]resource.Resource{unknown, newer, older})
+	assert.Equal(t, []string{"a", "b", "c"}, names(m.Resources()))
+
+	m.SetSorting(Sorting{Column: columnCreated, Descending: true})
+	assert.Equal(t, []string{"b", "a", "c"}, names(m.Resources()))
+	assert.Equal(t, "b", m.table.Rows()[0][1])
+
+	t.Run("sorting is kept on refresh", func(t *testing.T) {
+		m.UpdateResources([]resource.Resource{older, unknown, newer})
+		assert.Equal(t, []string{"b", "a", "c"}, names(m.Resources()))
+	})
+
+	t.Run("sorting is kept on events", func(t *testing.T) {
+		newest := mockResource("d")
+		evenLater := later.Add(time.Hour)
+		newest.MetadataValue.CreatedAt = &evenLater
+
+		m.ApplyEvents([]resource.IndexEvent{{Kind: resource.IndexEventUpsert, Resource: newest}}, nil)
+		assert.Equal(t, []string{"d", "b", "a", "c"}, names(m.Resources()))
+	})
+}
+
+func TestSorting_Next(t *testing.T) {
+	s := Sorting{Column: columnLocality, Descending: true}
+	assert.Equal(t, Sorting{Column: columnStatus}, s.Next())
+	assert.Equal(t, Sorting{Column: columnLocality}, s.Reversed())
+}
+
+func TestModel_TreeView(t *testing.T) {
+	project := scaleway.Project{ID: "project-id", Name: "project"}
+	namespace := fnc_sdk.Namespace{ID: "namespace-id", Name: "namespace", ProjectID: project.ID, Region: scw.RegionFrPar}
+	function := scaleway.Function{
+		Function:  fnc_sdk.Function{ID: "function-id", Name: "function", Region: scw.RegionFrPar},
+		Namespace: namespace,
+	}
+	orphan := mockResource("orphan")
+
+	m := Table(ui.ApplicationState{Keys: ui.DefaultKeyMap()})
+	m.SetDimensions(120, 40)
+	m.UpdateResources([]resource.Resource{function, scaleway.FunctionNamespace(namespace), orphan, project})
+
+	m.toggleTreeView()
+
+	// only the projects are expanded by default.
+	assert.Equal(t, []string{"orphan", "project", "namespace"}, names(m.visibleResources()))
+	assert.Equal(t, "  ▸ namespace (1)", m.table.Rows()[2][columnName])
+
+	m.table.SetCursor(2)
+	m.toggleNode()
+	assert.Equal(t, []string{"orphan", "project", "namespace", "function"}, names(m.visibleResources()))
+	assert.Equal(t, "      function", m.table.Rows()[3][columnName])
+
+	t.Run("selection is kept when leaving the tree view", func(t *testing.T) {
+		m.table.SetCursor(3)
+		m.toggleTreeView()
+		assert.Equal(t, "function", m.SelectedResource().Metadata().Name)
+	})
+}
+
+func TestBuildTree_UnknownParent(t *testing.T) {
+	// the Load Balancer of a frontend is not always known.
+	frontend := scaleway.LBFrontend{ID: "frontend-id", Name: "frontend"}
+
+	roots := buildTree([]resource.Resource{frontend})
+	require.Len(t, roots, 1)
+	assert.Equal(t, "frontend", roots[0].resource.Metadata().Name)
+}
+
+func TestModel_Marks(t *testing.T) {
+	a, b, c := mockResource("a"), mockResource("b"), mockResource("c")
+
+	m := Table(ui.ApplicationState{Keys: ui.DefaultKeyMap()})
+	m.SetDimensions(120, 40)
+	m.UpdateResources([]resource.Resource{a, b, c})
+
+	t.Run("selected resources are marked", func(t *testing.T) {
+		m.table.SetCursor(2)
+		m.toggleMark()
+		m.table.SetCursor(0)
+		m.toggleMark()
+
+		assert.Equal(t, []string{"a", "c"}, names(m.Marked()))
+	})
+
+	t.Run("marks follow the resources", func(t *testing.T) {
+		m.ApplyEvents([]resource.IndexEvent{
+			{Kind: resource.IndexEventUpsert, Resource: mockResource("0")},
+			{Kind: resource.IndexEventDelete, Resource: c},
+		}, nil)
+
+		assert.Equal(t, []string{"a"}, names(m.Marked()))
+	})
+
+	t.Run("all resources are marked, then cleared", func(t *testing.T) {
+		m.toggleMarkAll()
+		assert.Equal(t, []string{"0", "a", "b"}, names(m.Marked()))
+
+		m.toggleMarkAll()
+		assert.Empty(t, m.Marked())
+	})
+}
@@ -0,0 +1,92 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+)
+
+// treeNode is a resource along with the resources that belong to it.
+type treeNode struct {
+	resource resource.Resource
+	key      resourceKey
+	children []*treeNode
+}
+
+// buildTree nests the resources under their parent, or under their project if they have no parent.
+// The order of the resources is kept between siblings.
+func buildTree(resources []resource.Resource) []*treeNode {
+	nodes := make(map[resourceKey]*treeNode, len(resources))
+	projects := make(map[string]*treeNode)
+
+	for _, r := range resources {
+		k := keyOf(r)
+		nodes[k] = &treeNode{resource: r, key: k}
+		if k.Type == resource.TypeProject {
+			projects[k.ID] = nodes[k]
+		}
+	}
+
+	roots := make([]*treeNode, 0)
+	for _, r := range resources {
+		node := nodes[keyOf(r)]
+
+		// a resource whose parent is not known is shown like the other resources of its project.
+		if nested, ok := r.(resource.Nested); ok && nested.Parent() != nil {
+			if parent, ok := nodes[keyOf(nested.Parent())]; ok {
+				parent.children = append(parent.children, node)
+				continue
+			}
+		}
+
+		if node.key.Type != resource.TypeProject {
+			if project, ok := projects[r.Metadata().ProjectID]; ok {
+				project.children = append(project.children, node)
+				continue
+			}
+		}
+
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+// treeRow is a visible node of the tree.
+type treeRow struct {
+	node  *treeNode
+	depth int
+}
+
+// flattenTree returns the visible nodes, depth first.
+func flattenTree(roots []*treeNode, expanded func(node *treeNode, depth int) bool) []treeRow {
+	rows := make([]treeRow, 0, len(roots))
+
+	var walk func(nodes []*treeNode, depth int)
+	walk = func(nodes []*treeNode, depth int) {
+		for _, node := range nodes {
+			rows = append(rows, treeRow{node: node, depth: depth})
+			if len(node.children) > 0 && expanded(node, depth) {
+				walk(node.children, depth+1)
+			}
+		}
+	}
+	walk(roots, 0)
+
+	return rows
+}
+
+// decorate indents the name of the resource and shows whether the node is expanded.
+func (r treeRow) decorate(name string, expanded bool) string {
+	indent := strings.Repeat("  ", r.depth)
+
+	switch {
+	case len(r.node.children) == 0:
+		return indent + "  " + name
+	case expanded:
+		return indent + "▾ " + name
+	default:
+		return indent + "▸ " + name + fmt.Sprintf(" (%d)", len(r.node.children))
+	}
+}
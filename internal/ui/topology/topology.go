@@ -0,0 +1,141 @@
+package topology
+
+// A component to view the resources attached to a Private Network, with their address in it.
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/ui"
+)
+
+func Topology(state ui.ApplicationState, network resource.Networked, width, height int) Model {
+	return Model{
+		state:    state,
+		network:  network,
+		viewport: viewport.New(width, height),
+	}
+}
+
+// ResourcesMsg contains the indexed resources, to show the status of the attached ones.
+type ResourcesMsg struct {
+	Err       error
+	Resources []resource.Resource
+	NetworkID string
+}
+
+// Init initializes the topology component.
+func (m Model) Init() tea.Cmd {
+	return func() tea.Msg {
+		resources, err := m.state.Store.ListAllResources(context.Background())
+		return ResourcesMsg{
+			Err:       err,
+			Resources: resources,
+			NetworkID: m.network.Metadata().ID,
+		}
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(ResourcesMsg); ok {
+		if msg.NetworkID != m.network.Metadata().ID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.errorMsg = fmt.Sprintf("Error listing resources: %s", msg.Err)
+		}
+		m.viewport.SetContent(render(m.network.Attachments(), msg.Resources))
+		return m, nil
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+type attachmentKey struct {
+	ID   string
+	Type resource.Type
+}
+
+// render renders the attached resources, with their status if they are indexed.
+func render(attachments []resource.NetworkAttachment, resources []resource.Resource) string {
+	if len(attachments) == 0 {
+		return "No resources are attached to this network."
+	}
+
+	indexed := make(map[attachmentKey]resource.Resource, len(resources))
+	for _, r := range resources {
+		metadata := r.Metadata()
+		indexed[attachmentKey{ID: metadata.ID, Type: metadata.Type}] = r
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME\tLOCALITY\tADDRESS\tSTATUS")
+	for _, a := range attachments {
+		address := a.Address
+		if address == "" {
+			address = "-"
+		}
+
+		// the resource may belong to a project that is not indexed.
+		status := "not indexed"
+		if r, ok := indexed[attachmentKey{ID: a.ID, Type: a.Type}]; ok {
+			status = "-"
+			if s := r.Metadata().Status; s != nil {
+				status = fmt.Sprintf("%c %s", s.Emoji(a.Type), *s)
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.Type, a.Name, a.Locality, address, status)
+	}
+	_ = w.Flush()
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m Model) viewHeader() string {
+	metadata := m.network.Metadata()
+	header := m.state.Styles.Title.Render("Topology of " + strings.ToLower(metadata.Type.String()) + " " + metadata.Name)
+	if metadata.Description != nil && *metadata.Description != "" {
+		header += m.state.Styles.Title.Render("(" + *metadata.Description + ")")
+	}
+
+	if m.errorMsg != "" {
+		header += "\n" + m.state.Styles.Error.Render(m.errorMsg)
+	}
+
+	return header
+}
+
+func (m Model) View() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		m.viewHeader(),
+		m.state.Styles.BaseBorder.Width(m.viewport.Width).Render(m.viewport.View()),
+	)
+}
+
+func (m *Model) SetDimensions(width, height int) {
+	m.viewport.Width = width
+	m.viewport.Height = height
+}
+
+type Model struct {
+	// state of the application
+	state ui.ApplicationState
+	// network whose attached resources are shown
+	network resource.Networked
+	// viewport to display the attached resources
+	viewport viewport.Model
+
+	errorMsg string
+}
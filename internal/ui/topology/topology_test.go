@@ -0,0 +1,35 @@
+package topology
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cyclimse/scwtui/internal/resource"
+	"github.com/cyclimse/scwtui/internal/resource/scaleway"
+	instance_sdk "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	attachments := []resource.NetworkAttachment{
+		{ID: "server-id", Name: "server", Type: resource.TypeInstance, Locality: "fr-par-1", Address: "172.16.0.2"},
+		{ID: "cluster-id", Name: "cluster", Type: resource.TypeKapsuleCluster, Locality: "fr-par"},
+	}
+	resources := []resource.Resource{
+		scaleway.Instance(instance_sdk.Server{ID: "server-id", Name: "server", State: instance_sdk.ServerStateRunning}),
+	}
+
+	lines := strings.Split(render(attachments, resources), "\n")
+	require.Len(t, lines, 3)
+
+	assert.Contains(t, lines[0], "ADDRESS")
+	assert.Contains(t, lines[1], "172.16.0.2")
+	assert.Contains(t, lines[1], "running")
+	assert.Contains(t, lines[2], "Kapsule Cluster")
+	assert.Contains(t, lines[2], "not indexed", "the cluster is in a project that is not indexed")
+}
+
+func TestRender_NoAttachments(t *testing.T) {
+	assert.Equal(t, "No resources are attached to this network.", render(nil, nil))
+}